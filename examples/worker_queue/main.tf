@@ -28,12 +28,22 @@ resource "controlplane_worker_queue" "example" {
   heartbeat_interval = 60 # Default changed from 30 to 60 (lightweight heartbeats)
   max_workers        = 10
 
+  # On destroy, pause the queue and wait up to 10 minutes for workers to drain
+  drain_timeout = 600
+
   tags = ["production", "primary"]
 
-  settings = {
+  settings = jsonencode({
     region = "us-east-1"
     tier   = "production"
-  }
+    autoscaling = {
+      enabled              = true
+      min_workers          = 2
+      max_workers          = 10
+      scale_up_threshold   = 80
+      scale_down_threshold = 20
+    }
+  })
 }
 
 # Data source example - fetch a worker queue by ID
@@ -67,6 +77,16 @@ output "active_workers" {
   description = "Number of active workers in the queue"
 }
 
+output "worker_kubernetes_manifest" {
+  value       = controlplane_worker_queue.example.kubernetes_manifest
+  description = "Kubernetes Deployment manifest for workers of this queue"
+}
+
+output "worker_docker_run_command" {
+  value       = controlplane_worker_queue.example.docker_run_command
+  description = "docker run command that starts a worker for this queue"
+}
+
 output "all_queues_count" {
   value       = length(data.controlplane_worker_queues.all.queues)
   description = "Total number of worker queues in the environment"
@@ -1,78 +1,39 @@
 package clients
 
 import (
-	"fmt"
-	"net/http"
+	"context"
+	"iter"
 
 	"terraform-provider-kubiya-control-plane/internal/entities"
 )
 
 // CreateAgent creates a new agent
-func (c *Client) CreateAgent(req *entities.AgentCreateRequest) (*entities.Agent, error) {
-	resp, err := c.DoRequest(http.MethodPost, "/api/v1/agents", req)
-	if err != nil {
-		return nil, err
-	}
-
-	var agent entities.Agent
-	if err := ParseResponse(resp, &agent); err != nil {
-		return nil, err
-	}
-
-	return &agent, nil
+func (c *Client) CreateAgent(ctx context.Context, req *entities.AgentCreateRequest) (*entities.Agent, error) {
+	return c.api.CreateAgent(c.context(ctx), req)
 }
 
 // GetAgent retrieves an agent by ID
-func (c *Client) GetAgent(id string) (*entities.Agent, error) {
-	resp, err := c.DoRequest(http.MethodGet, fmt.Sprintf("/api/v1/agents/%s", id), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var agent entities.Agent
-	if err := ParseResponse(resp, &agent); err != nil {
-		return nil, err
-	}
-
-	return &agent, nil
+func (c *Client) GetAgent(ctx context.Context, id string) (*entities.Agent, error) {
+	return c.api.GetAgent(c.context(ctx), id)
 }
 
 // UpdateAgent updates an existing agent
-func (c *Client) UpdateAgent(id string, req *entities.AgentUpdateRequest) (*entities.Agent, error) {
-	resp, err := c.DoRequest(http.MethodPatch, fmt.Sprintf("/api/v1/agents/%s", id), req)
-	if err != nil {
-		return nil, err
-	}
-
-	var agent entities.Agent
-	if err := ParseResponse(resp, &agent); err != nil {
-		return nil, err
-	}
-
-	return &agent, nil
+func (c *Client) UpdateAgent(ctx context.Context, id string, req *entities.AgentUpdateRequest) (*entities.Agent, error) {
+	c.MarkWritten(id)
+	return c.api.UpdateAgent(c.context(ctx), id, req)
 }
 
 // DeleteAgent deletes an agent
-func (c *Client) DeleteAgent(id string) error {
-	resp, err := c.DoRequest(http.MethodDelete, fmt.Sprintf("/api/v1/agents/%s", id), nil)
-	if err != nil {
-		return err
-	}
-
-	return ParseResponse(resp, nil)
+func (c *Client) DeleteAgent(ctx context.Context, id string) error {
+	return c.api.DeleteAgent(c.context(ctx), id)
 }
 
 // ListAgents lists all agents
-func (c *Client) ListAgents() ([]*entities.Agent, error) {
-	resp, err := c.DoRequest(http.MethodGet, "/api/v1/agents", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var agents []*entities.Agent
-	if err := ParseResponse(resp, &agents); err != nil {
-		return nil, err
-	}
+func (c *Client) ListAgents(ctx context.Context) ([]*entities.Agent, error) {
+	return c.api.ListAgents(c.context(ctx), c.listOptions(nil))
+}
 
-	return agents, nil
+// IterAgents iterates over all agents, fetching them page by page
+func (c *Client) IterAgents(ctx context.Context, opts *ListOptions) iter.Seq2[*entities.Agent, error] {
+	return c.api.IterAgents(c.context(ctx), c.listOptions(opts))
 }
@@ -0,0 +1,103 @@
+package clients
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+
+	kubiyasentry "terraform-provider-kubiya-control-plane/internal/sentry"
+)
+
+// readCache keeps successful GET responses for the lifetime of a client and collapses concurrent
+// identical GETs into a single request. Every write empties it.
+type readCache struct {
+	mu         sync.Mutex
+	entries    map[string]*cachedResponse
+	generation uint64
+	group      singleflight.Group
+}
+
+// cachedResponse is a fully read HTTP response that can be replayed any number of times
+type cachedResponse struct {
+	statusCode int
+	status     string
+	header     http.Header
+	body       []byte
+}
+
+func newReadCache() *readCache {
+	return &readCache{entries: make(map[string]*cachedResponse)}
+}
+
+// get returns the cached response for path, or performs the request once for all concurrent callers
+func (rc *readCache) get(path string, do func() (*http.Response, error)) (*http.Response, error) {
+	rc.mu.Lock()
+	entry, ok := rc.entries[path]
+	generation := rc.generation
+	rc.mu.Unlock()
+
+	if ok {
+		kubiyasentry.GetLogger().Debug("Serving GET from read cache", "path", path)
+		return entry.response(), nil
+	}
+
+	result, err, _ := rc.group.Do(path, func() (interface{}, error) {
+		resp, err := do()
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		entry := &cachedResponse{
+			statusCode: resp.StatusCode,
+			status:     resp.Status,
+			header:     resp.Header,
+			body:       body,
+		}
+
+		// Only keep successful responses, and only if no write happened while the request was in flight
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			rc.mu.Lock()
+			if rc.generation == generation {
+				rc.entries[path] = entry
+			}
+			rc.mu.Unlock()
+		}
+
+		return entry, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*cachedResponse).response(), nil
+}
+
+// invalidate drops every entry. A write can change objects other than the one at its path, e.g.
+// adding a team member changes the agent, so no cached read is assumed to survive it.
+func (rc *readCache) invalidate() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.generation++
+	clear(rc.entries)
+}
+
+// response builds a fresh *http.Response whose body can be consumed independently of other callers
+func (cr *cachedResponse) response() *http.Response {
+	return &http.Response{
+		StatusCode: cr.statusCode,
+		Status:     cr.status,
+		Header:     cr.header.Clone(),
+		Body:       io.NopCloser(bytes.NewReader(cr.body)),
+	}
+}
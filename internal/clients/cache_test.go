@@ -0,0 +1,127 @@
+package clients
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// respond returns a request function that counts its calls and answers with status and body
+func respond(calls *atomic.Int32, status int, body string) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		calls.Add(1)
+		return &http.Response{
+			StatusCode: status,
+			Status:     http.StatusText(status),
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	}
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestReadCacheServesRepeatedGets(t *testing.T) {
+	cache := newReadCache()
+	var calls atomic.Int32
+
+	for range 3 {
+		resp, err := cache.get("/api/v1/agents/a1", respond(&calls, http.StatusOK, `{"id":"a1"}`))
+		require.NoError(t, err)
+		assert.Equal(t, `{"id":"a1"}`, readBody(t, resp))
+	}
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestReadCacheSkipsErrors(t *testing.T) {
+	cache := newReadCache()
+	var calls atomic.Int32
+
+	for range 2 {
+		resp, err := cache.get("/api/v1/agents/a1", respond(&calls, http.StatusNotFound, `{"detail":"Agent not found"}`))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestReadCacheCollapsesConcurrentGets(t *testing.T) {
+	cache := newReadCache()
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	// Errors are never cached, so a single call means the concurrent requests shared it
+	do := func() (*http.Response, error) {
+		<-release
+		return respond(&calls, http.StatusServiceUnavailable, "unavailable")()
+	}
+
+	var wg sync.WaitGroup
+	bodies := make([]string, 10)
+	for i := range bodies {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := cache.get("/api/v1/teams", do)
+			if assert.NoError(t, err) {
+				bodies[i] = readBody(t, resp)
+			}
+		}()
+	}
+
+	// Give every caller time to join the request in flight before it completes
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for _, body := range bodies {
+		assert.Equal(t, "unavailable", body, "every caller must get its own readable copy of the body")
+	}
+}
+
+func TestReadCacheDropsResponsesRacingAWrite(t *testing.T) {
+	cache := newReadCache()
+	var calls atomic.Int32
+
+	// The agent is updated while it is being read: the response may predate the write
+	resp, err := cache.get("/api/v1/agents/a1", func() (*http.Response, error) {
+		cache.invalidate()
+		return respond(&calls, http.StatusOK, `{"name":"old"}`)()
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"old"}`, readBody(t, resp))
+
+	resp, err = cache.get("/api/v1/agents/a1", respond(&calls, http.StatusOK, `{"name":"new"}`))
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"new"}`, readBody(t, resp))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestReadCacheInvalidate(t *testing.T) {
+	cache := newReadCache()
+	var calls atomic.Int32
+	for _, path := range []string{"/api/v1/agents/a1", "/api/v1/agents?skip=0&limit=100", "/api/v1/teams/t1"} {
+		_, err := cache.get(path, respond(&calls, http.StatusOK, "{}"))
+		require.NoError(t, err)
+	}
+
+	// Adding a team member changes the agent too, so every entry goes
+	cache.invalidate()
+	assert.Empty(t, cache.entries)
+}
@@ -2,7 +2,7 @@ package clients
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	kubiyasentry "terraform-provider-kubiya-control-plane/internal/sentry"
+	"terraform-provider-kubiya-control-plane/pkg/controlplane"
 
 	"github.com/getsentry/sentry-go"
 )
@@ -18,6 +19,25 @@ type Client struct {
 	APIKey     string
 	BaseURL    string
 	HTTPClient *http.Client
+	// PageSize is the default number of items requested per page by list calls
+	PageSize int
+	// SkipConflictCheck disables the updated_at precondition resources check before updating
+	SkipConflictCheck bool
+	// UserAgent, when set, replaces the SDK's User-Agent header
+	UserAgent string
+
+	// cache holds GET responses when the read cache is enabled
+	cache *readCache
+	// bypassCache makes GETs skip the read cache while writes still invalidate it
+	bypassCache bool
+	// limiter throttles requests and adapts to the server's rate limit headers
+	limiter *rateLimiter
+	// skillDefinitions holds the skill definitions once they have been listed
+	skillDefinitions *skillDefinitions
+	// written holds the IDs of the objects this client changed, which the conflict check skips
+	written *writtenObjects
+	// api is the public SDK client that builds every request; pipeline is its transport
+	api *controlplane.Client
 }
 
 // New creates a new Control Plane API client
@@ -32,20 +52,51 @@ func New(apiKey string) (*Client, error) {
 
 	baseURL := getBaseURL()
 
+	// Record or replay API traffic when configured, e.g. for offline acceptance tests
+	transport := http.DefaultTransport
+	recorderMode, cassetteDir := getRecorderConfig()
+	if recorderMode != RecorderOff {
+		recorder, err := NewRecorder(recorderMode, cassetteDir, transport)
+		if err != nil {
+			logger.Error("Failed to create client", "error", err.Error())
+			return nil, err
+		}
+		transport = recorder
+	}
+
 	// Create HTTP client with Sentry tracing transport
 	httpClient := &http.Client{
 		Timeout:   60 * time.Second,
-		Transport: kubiyasentry.NewHTTPTransport(http.DefaultTransport),
+		Transport: kubiyasentry.NewHTTPTransport(transport),
 	}
 
 	client := &Client{
-		APIKey:     apiKey,
-		BaseURL:    baseURL,
-		HTTPClient: httpClient,
+		APIKey:           apiKey,
+		BaseURL:          baseURL,
+		HTTPClient:       httpClient,
+		PageSize:         getPageSize(),
+		limiter:          newRateLimiter(RateLimitConfig{}),
+		skillDefinitions: &skillDefinitions{},
+		written:          &writtenObjects{ids: map[string]bool{}},
 	}
 
+	// Retries are left to the rate limiter, which honors the server's rate limit headers
+	api, err := controlplane.New(
+		controlplane.WithAPIKey(apiKey),
+		controlplane.WithBaseURL(baseURL),
+		controlplane.WithHTTPClient(&http.Client{Transport: pipeline{client: client}}),
+		controlplane.WithRetries(0),
+		controlplane.WithPageSize(client.PageSize),
+	)
+	if err != nil {
+		logger.Error("Failed to create client", "error", err.Error())
+		return nil, err
+	}
+	client.api = api
+
 	logger.Info("Created Kubiya Control Plane client",
 		"base_url", baseURL,
+		"recorder_mode", string(recorderMode),
 	)
 
 	kubiyasentry.AddBreadcrumb("client", "Kubiya Control Plane client created", sentry.LevelInfo, map[string]interface{}{
@@ -68,46 +119,144 @@ func getBaseURL() string {
 	return "https://control-plane.kubiya.ai"
 }
 
-// DoRequest performs an HTTP request with proper headers
-func (c *Client) DoRequest(method, path string, body interface{}) (*http.Response, error) {
+// Uncached returns a client that shares this client's configuration but always fetches fresh data.
+// Use it when polling for state changes; writes made through it still invalidate the read cache.
+func (c *Client) Uncached() *Client {
+	uncached := *c
+	uncached.bypassCache = true
+	return &uncached
+}
+
+// bypassCacheKey marks the context of requests that must not be served from the read cache
+type bypassCacheKey struct{}
+
+// context returns the context for requests made through the SDK client. Requests are bound to ctx,
+// so that Terraform's cancellation and deadlines stop them, including waits for the rate limits.
+func (c *Client) context(ctx context.Context) context.Context {
+	if c.bypassCache {
+		ctx = context.WithValue(ctx, bypassCacheKey{}, true)
+	}
+
+	return ctx
+}
+
+// pipeline is the transport of the SDK client. It applies the read cache and the rate limits before
+// handing requests to HTTPClient.
+type pipeline struct {
+	client *Client
+}
+
+// RoundTrip implements the http.RoundTripper interface. When the read cache is enabled, GETs are
+// served from it and any other method empties it.
+func (p pipeline) RoundTrip(req *http.Request) (*http.Response, error) {
+	c := p.client
+	path := req.URL.RequestURI()
+
+	if c.cache == nil {
+		return c.doRequest(req)
+	}
+
+	if req.Method != http.MethodGet {
+		defer c.cache.invalidate()
+		return c.doRequest(req)
+	}
+
+	if bypass, _ := req.Context().Value(bypassCacheKey{}).(bool); bypass {
+		return c.doRequest(req)
+	}
+
+	return c.cache.get(path, func() (*http.Response, error) {
+		return c.doRequest(req)
+	})
+}
+
+// EnableReadCache makes the client cache GET responses for its lifetime, or until the next write
+func (c *Client) EnableReadCache() {
+	c.cache = newReadCache()
+}
+
+// SetRateLimits replaces the client-side rate limit and concurrency cap
+func (c *Client) SetRateLimits(config RateLimitConfig) {
+	c.limiter = newRateLimiter(config)
+}
+
+// doRequest sends a request to the API within the configured rate limits, retrying requests the
+// server rejects with 429 Too Many Requests
+func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
 	logger := kubiyasentry.GetLogger()
-	var bodyReader io.Reader
-	var jsonBody []byte
 
-	if body != nil {
-		var err error
-		jsonBody, err = json.Marshal(body)
+	for attempt := 0; ; attempt++ {
+		release, err := c.limiter.acquire(req.Context())
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, err
+		}
+		resp, err := c.send(req)
+		release()
+		if err != nil {
+			return nil, err
 		}
-		bodyReader = bytes.NewBuffer(jsonBody)
-	}
 
-	fullURL := c.BaseURL + path
-	req, err := http.NewRequest(method, fullURL, bodyReader)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		c.limiter.observe(resp)
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= maxRateLimitRetries {
+			return resp, nil
+		}
+
+		_ = resp.Body.Close()
+		delay := retryDelay(resp, attempt)
+		logger.Warn("Rate limited by the API, retrying",
+			"method", req.Method,
+			"path", req.URL.Path,
+			"attempt", attempt+1,
+			"delay", delay.String(),
+		)
+		c.limiter.pause(time.Now().Add(delay))
 	}
+}
 
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+// send performs a single HTTP request through HTTPClient, logging the details of error responses
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	logger := kubiyasentry.GetLogger()
+
+	// Every attempt needs its own copy of the body
+	attempt := req.Clone(req.Context())
+	if c.UserAgent != "" {
+		attempt.Header.Set("User-Agent", c.UserAgent)
+	}
+	requestID := req.Header.Get(controlplane.RequestIDHeader)
+	var jsonBody []byte
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		jsonBody, _ = io.ReadAll(body)
+		attempt.Body = io.NopCloser(bytes.NewReader(jsonBody))
+	}
 
+	fullURL := req.URL.String()
 	startTime := time.Now()
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.HTTPClient.Do(attempt)
 	duration := time.Since(startTime)
 
 	if err != nil {
 		logger.Error("HTTP request failed",
-			"method", method,
+			"method", req.Method,
 			"url", fullURL,
+			"request_id", requestID,
 			"error", err.Error(),
 		)
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, err
 	}
 
-	// Log request details if there's an error response
+	// Log request and response details if there's an error response
 	if resp.StatusCode >= 400 {
+		bodyBytes, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+
 		logFile := os.Getenv("KUBIYA_API_LOG_FILE")
 		if logFile == "" {
 			logFile = "/tmp/kubiya_api_errors.log"
@@ -118,8 +267,9 @@ func (c *Client) DoRequest(method, path string, body interface{}) (*http.Respons
 			defer f.Close()
 			fmt.Fprintf(f, "\n========== API ERROR ==========\n")
 			fmt.Fprintf(f, "Time: %s\n", time.Now().Format(time.RFC3339))
-			fmt.Fprintf(f, "Method: %s\n", method)
+			fmt.Fprintf(f, "Method: %s\n", req.Method)
 			fmt.Fprintf(f, "URL: %s\n", fullURL)
+			fmt.Fprintf(f, "Request ID: %s\n", requestID)
 			fmt.Fprintf(f, "Status Code: %d\n", resp.StatusCode)
 			fmt.Fprintf(f, "Duration: %dms\n", duration.Milliseconds())
 			fmt.Fprintf(f, "\n--- Request Headers ---\n")
@@ -131,43 +281,6 @@ func (c *Client) DoRequest(method, path string, body interface{}) (*http.Respons
 			if len(jsonBody) > 0 {
 				fmt.Fprintf(f, "\n--- Request Body ---\n%s\n", string(jsonBody))
 			}
-			fmt.Fprintf(f, "===============================\n\n")
-		}
-
-		logger.Error("API Error - Full Request Details",
-			"method", method,
-			"url", fullURL,
-			"status_code", resp.StatusCode,
-			"duration_ms", duration.Milliseconds(),
-			"request_body", string(jsonBody),
-			"log_file", logFile,
-		)
-	}
-
-	return resp, nil
-}
-
-// ParseResponse parses the HTTP response into the provided interface
-func ParseResponse(resp *http.Response, target interface{}) error {
-	logger := kubiyasentry.GetLogger()
-	defer func() { _ = resp.Body.Close() }()
-
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		logFile := os.Getenv("KUBIYA_API_LOG_FILE")
-		if logFile == "" {
-			logFile = "/tmp/kubiya_api_errors.log"
-		}
-
-		f, err := os.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-		if err == nil {
-			defer f.Close()
-			fmt.Fprintf(f, "--- Response Status ---\n")
-			fmt.Fprintf(f, "Status Code: %d\n", resp.StatusCode)
 			fmt.Fprintf(f, "\n--- Response Headers ---\n")
 			for k, v := range resp.Header {
 				fmt.Fprintf(f, "%s: %v\n", k, v)
@@ -176,25 +289,19 @@ func ParseResponse(resp *http.Response, target interface{}) error {
 			fmt.Fprintf(f, "===============================\n\n")
 		}
 
-		logger.Error("API Error - Full Response Details",
+		logger.Error("API Error - Full Request Details",
+			"method", req.Method,
+			"url", fullURL,
 			"status_code", resp.StatusCode,
+			"request_id", requestID,
+			"server_request_id", resp.Header.Get(controlplane.RequestIDHeader),
+			"duration_ms", duration.Milliseconds(),
+			"request_body", string(jsonBody),
 			"response_body", string(bodyBytes),
-			"response_headers", fmt.Sprintf("%v", resp.Header),
 			"content_type", resp.Header.Get("Content-Type"),
 			"log_file", logFile,
 		)
-		return fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(bodyBytes))
 	}
 
-	if target != nil && len(bodyBytes) > 0 {
-		if err := json.Unmarshal(bodyBytes, target); err != nil {
-			logger.Error("Failed to parse response body",
-				"error", err.Error(),
-				"response_body", string(bodyBytes),
-			)
-			return fmt.Errorf("failed to parse response: %w", err)
-		}
-	}
-
-	return nil
+	return resp, nil
 }
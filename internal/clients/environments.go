@@ -1,78 +1,39 @@
 package clients
 
 import (
-	"fmt"
-	"net/http"
+	"context"
+	"iter"
 
 	"terraform-provider-kubiya-control-plane/internal/entities"
 )
 
 // CreateEnvironment creates a new environment
-func (c *Client) CreateEnvironment(req *entities.EnvironmentCreateRequest) (*entities.Environment, error) {
-	resp, err := c.DoRequest(http.MethodPost, "/api/v1/environments", req)
-	if err != nil {
-		return nil, err
-	}
-
-	var environment entities.Environment
-	if err := ParseResponse(resp, &environment); err != nil {
-		return nil, err
-	}
-
-	return &environment, nil
+func (c *Client) CreateEnvironment(ctx context.Context, req *entities.EnvironmentCreateRequest) (*entities.Environment, error) {
+	return c.api.CreateEnvironment(c.context(ctx), req)
 }
 
 // GetEnvironment retrieves an environment by ID
-func (c *Client) GetEnvironment(id string) (*entities.Environment, error) {
-	resp, err := c.DoRequest(http.MethodGet, fmt.Sprintf("/api/v1/environments/%s", id), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var environment entities.Environment
-	if err := ParseResponse(resp, &environment); err != nil {
-		return nil, err
-	}
-
-	return &environment, nil
+func (c *Client) GetEnvironment(ctx context.Context, id string) (*entities.Environment, error) {
+	return c.api.GetEnvironment(c.context(ctx), id)
 }
 
 // UpdateEnvironment updates an existing environment
-func (c *Client) UpdateEnvironment(id string, req *entities.EnvironmentUpdateRequest) (*entities.Environment, error) {
-	resp, err := c.DoRequest(http.MethodPatch, fmt.Sprintf("/api/v1/environments/%s", id), req)
-	if err != nil {
-		return nil, err
-	}
-
-	var environment entities.Environment
-	if err := ParseResponse(resp, &environment); err != nil {
-		return nil, err
-	}
-
-	return &environment, nil
+func (c *Client) UpdateEnvironment(ctx context.Context, id string, req *entities.EnvironmentUpdateRequest) (*entities.Environment, error) {
+	c.MarkWritten(id)
+	return c.api.UpdateEnvironment(c.context(ctx), id, req)
 }
 
 // DeleteEnvironment deletes an environment
-func (c *Client) DeleteEnvironment(id string) error {
-	resp, err := c.DoRequest(http.MethodDelete, fmt.Sprintf("/api/v1/environments/%s", id), nil)
-	if err != nil {
-		return err
-	}
-
-	return ParseResponse(resp, nil)
+func (c *Client) DeleteEnvironment(ctx context.Context, id string) error {
+	return c.api.DeleteEnvironment(c.context(ctx), id)
 }
 
 // ListEnvironments lists all environments
-func (c *Client) ListEnvironments() ([]*entities.Environment, error) {
-	resp, err := c.DoRequest(http.MethodGet, "/api/v1/environments", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var environments []*entities.Environment
-	if err := ParseResponse(resp, &environments); err != nil {
-		return nil, err
-	}
+func (c *Client) ListEnvironments(ctx context.Context) ([]*entities.Environment, error) {
+	return c.api.ListEnvironments(c.context(ctx), c.listOptions(nil))
+}
 
-	return environments, nil
+// IterEnvironments iterates over all environments, fetching them page by page
+func (c *Client) IterEnvironments(ctx context.Context, opts *ListOptions) iter.Seq2[*entities.Environment, error] {
+	return c.api.IterEnvironments(c.context(ctx), c.listOptions(opts))
 }
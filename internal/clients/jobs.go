@@ -1,108 +1,51 @@
 package clients
 
 import (
-	"fmt"
-	"net/http"
+	"context"
+	"iter"
 
 	"terraform-provider-kubiya-control-plane/internal/entities"
 )
 
 // CreateJob creates a new job
-func (c *Client) CreateJob(req *entities.JobCreateRequest) (*entities.Job, error) {
-	resp, err := c.DoRequest(http.MethodPost, "/api/v1/jobs", req)
-	if err != nil {
-		return nil, err
-	}
-
-	var job entities.Job
-	if err := ParseResponse(resp, &job); err != nil {
-		return nil, err
-	}
-
-	return &job, nil
+func (c *Client) CreateJob(ctx context.Context, req *entities.JobCreateRequest) (*entities.Job, error) {
+	return c.api.CreateJob(c.context(ctx), req)
 }
 
 // GetJob retrieves a job by ID
-func (c *Client) GetJob(id string) (*entities.Job, error) {
-	resp, err := c.DoRequest(http.MethodGet, fmt.Sprintf("/api/v1/jobs/%s", id), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var job entities.Job
-	if err := ParseResponse(resp, &job); err != nil {
-		return nil, err
-	}
-
-	return &job, nil
+func (c *Client) GetJob(ctx context.Context, id string) (*entities.Job, error) {
+	return c.api.GetJob(c.context(ctx), id)
 }
 
 // UpdateJob updates an existing job
-func (c *Client) UpdateJob(id string, req *entities.JobUpdateRequest) (*entities.Job, error) {
-	resp, err := c.DoRequest(http.MethodPatch, fmt.Sprintf("/api/v1/jobs/%s", id), req)
-	if err != nil {
-		return nil, err
-	}
-
-	var job entities.Job
-	if err := ParseResponse(resp, &job); err != nil {
-		return nil, err
-	}
-
-	return &job, nil
+func (c *Client) UpdateJob(ctx context.Context, id string, req *entities.JobUpdateRequest) (*entities.Job, error) {
+	c.MarkWritten(id)
+	return c.api.UpdateJob(c.context(ctx), id, req)
 }
 
 // DeleteJob deletes a job
-func (c *Client) DeleteJob(id string) error {
-	resp, err := c.DoRequest(http.MethodDelete, fmt.Sprintf("/api/v1/jobs/%s", id), nil)
-	if err != nil {
-		return err
-	}
-
-	return ParseResponse(resp, nil)
+func (c *Client) DeleteJob(ctx context.Context, id string) error {
+	return c.api.DeleteJob(c.context(ctx), id)
 }
 
 // ListJobs lists all jobs
-func (c *Client) ListJobs() ([]*entities.Job, error) {
-	resp, err := c.DoRequest(http.MethodGet, "/api/v1/jobs", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var jobs []*entities.Job
-	if err := ParseResponse(resp, &jobs); err != nil {
-		return nil, err
-	}
+func (c *Client) ListJobs(ctx context.Context) ([]*entities.Job, error) {
+	return c.api.ListJobs(c.context(ctx), c.listOptions(nil))
+}
 
-	return jobs, nil
+// IterJobs iterates over all jobs, fetching them page by page
+func (c *Client) IterJobs(ctx context.Context, opts *ListOptions) iter.Seq2[*entities.Job, error] {
+	return c.api.IterJobs(c.context(ctx), c.listOptions(opts))
 }
 
 // EnableJob enables a job
-func (c *Client) EnableJob(id string) (*entities.Job, error) {
-	resp, err := c.DoRequest(http.MethodPost, fmt.Sprintf("/api/v1/jobs/%s/enable", id), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var job entities.Job
-	if err := ParseResponse(resp, &job); err != nil {
-		return nil, err
-	}
-
-	return &job, nil
+func (c *Client) EnableJob(ctx context.Context, id string) (*entities.Job, error) {
+	c.MarkWritten(id)
+	return c.api.EnableJob(c.context(ctx), id)
 }
 
 // DisableJob disables a job
-func (c *Client) DisableJob(id string) (*entities.Job, error) {
-	resp, err := c.DoRequest(http.MethodPost, fmt.Sprintf("/api/v1/jobs/%s/disable", id), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var job entities.Job
-	if err := ParseResponse(resp, &job); err != nil {
-		return nil, err
-	}
-
-	return &job, nil
+func (c *Client) DisableJob(ctx context.Context, id string) (*entities.Job, error) {
+	c.MarkWritten(id)
+	return c.api.DisableJob(c.context(ctx), id)
 }
@@ -0,0 +1,45 @@
+package clients
+
+import (
+	"os"
+	"strconv"
+
+	"terraform-provider-kubiya-control-plane/pkg/controlplane"
+)
+
+// DefaultPageSize is the number of items requested per page when listing objects
+const DefaultPageSize = controlplane.DefaultPageSize
+
+// MaxPageSize is the largest page size the API accepts
+const MaxPageSize = controlplane.MaxPageSize
+
+// ListOptions controls paging and server-side filtering of list requests
+type ListOptions = controlplane.ListOptions
+
+// getPageSize returns the page size for list requests
+// Default: 100
+// Override with KUBIYA_CONTROL_PLANE_PAGE_SIZE environment variable; the page_size provider attribute
+// takes precedence over both
+func getPageSize() int {
+	if value := os.Getenv("KUBIYA_CONTROL_PLANE_PAGE_SIZE"); value != "" {
+		if size, err := strconv.Atoi(value); err == nil && size > 0 {
+			return min(size, MaxPageSize)
+		}
+	}
+
+	return DefaultPageSize
+}
+
+// listOptions applies the client page size to list options that do not set one
+func (c *Client) listOptions(opts *ListOptions) *ListOptions {
+	if opts != nil && opts.PageSize > 0 {
+		return opts
+	}
+
+	withPageSize := ListOptions{PageSize: c.PageSize}
+	if opts != nil {
+		withPageSize.Filters = opts.Filters
+	}
+
+	return &withPageSize
+}
@@ -1,78 +1,70 @@
 package clients
 
 import (
-	"fmt"
-	"net/http"
+	"context"
+	"iter"
 
 	"terraform-provider-kubiya-control-plane/internal/entities"
 )
 
 // CreatePolicy creates a new policy
-func (c *Client) CreatePolicy(req *entities.PolicyCreateRequest) (*entities.Policy, error) {
-	resp, err := c.DoRequest(http.MethodPost, "/api/v1/policies", req)
-	if err != nil {
-		return nil, err
-	}
-
-	var policy entities.Policy
-	if err := ParseResponse(resp, &policy); err != nil {
-		return nil, err
-	}
-
-	return &policy, nil
+func (c *Client) CreatePolicy(ctx context.Context, req *entities.PolicyCreateRequest) (*entities.Policy, error) {
+	return c.api.CreatePolicy(c.context(ctx), req)
 }
 
 // GetPolicy retrieves a policy by ID
-func (c *Client) GetPolicy(id string) (*entities.Policy, error) {
-	resp, err := c.DoRequest(http.MethodGet, fmt.Sprintf("/api/v1/policies/%s", id), nil)
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) GetPolicy(ctx context.Context, id string) (*entities.Policy, error) {
+	return c.api.GetPolicy(c.context(ctx), id)
+}
 
-	var policy entities.Policy
-	if err := ParseResponse(resp, &policy); err != nil {
-		return nil, err
-	}
+// UpdatePolicy updates an existing policy
+func (c *Client) UpdatePolicy(ctx context.Context, id string, req *entities.PolicyUpdateRequest) (*entities.Policy, error) {
+	c.MarkWritten(id)
+	return c.api.UpdatePolicy(c.context(ctx), id, req)
+}
 
-	return &policy, nil
+// DeletePolicy deletes a policy
+func (c *Client) DeletePolicy(ctx context.Context, id string) error {
+	return c.api.DeletePolicy(c.context(ctx), id)
 }
 
-// UpdatePolicy updates an existing policy
-func (c *Client) UpdatePolicy(id string, req *entities.PolicyUpdateRequest) (*entities.Policy, error) {
-	resp, err := c.DoRequest(http.MethodPut, fmt.Sprintf("/api/v1/policies/%s", id), req)
-	if err != nil {
-		return nil, err
-	}
+// ListPolicies lists all policies
+func (c *Client) ListPolicies(ctx context.Context) ([]*entities.Policy, error) {
+	return c.api.ListPolicies(c.context(ctx), c.listOptions(nil))
+}
 
-	var policy entities.Policy
-	if err := ParseResponse(resp, &policy); err != nil {
-		return nil, err
-	}
+// IterPolicies iterates over all policies, fetching them page by page
+func (c *Client) IterPolicies(ctx context.Context, opts *ListOptions) iter.Seq2[*entities.Policy, error] {
+	return c.api.IterPolicies(c.context(ctx), c.listOptions(opts))
+}
 
-	return &policy, nil
+// GetPolicyVersion retrieves one version of a policy's content
+func (c *Client) GetPolicyVersion(ctx context.Context, policyID string, version int64) (*entities.PolicyVersion, error) {
+	return c.api.GetPolicyVersion(c.context(ctx), policyID, version)
 }
 
-// DeletePolicy deletes a policy
-func (c *Client) DeletePolicy(id string) error {
-	resp, err := c.DoRequest(http.MethodDelete, fmt.Sprintf("/api/v1/policies/%s", id), nil)
-	if err != nil {
-		return err
-	}
+// ListPolicyVersions lists every version of a policy's content, oldest first
+func (c *Client) ListPolicyVersions(ctx context.Context, policyID string) ([]*entities.PolicyVersion, error) {
+	return c.api.ListPolicyVersions(c.context(ctx), policyID, c.listOptions(nil))
+}
 
-	return ParseResponse(resp, nil)
+// CreatePolicyAssociation attaches a policy to an agent, team, environment or project
+func (c *Client) CreatePolicyAssociation(ctx context.Context, req *entities.PolicyAssociationCreateRequest) (*entities.PolicyAssociation, error) {
+	c.MarkWritten(req.PolicyID, req.EntityID)
+	return c.api.CreatePolicyAssociation(c.context(ctx), req)
 }
 
-// ListPolicies lists all policies
-func (c *Client) ListPolicies() ([]*entities.Policy, error) {
-	resp, err := c.DoRequest(http.MethodGet, "/api/v1/policies", nil)
-	if err != nil {
-		return nil, err
-	}
+// GetPolicyAssociation retrieves a policy association by ID
+func (c *Client) GetPolicyAssociation(ctx context.Context, id string) (*entities.PolicyAssociation, error) {
+	return c.api.GetPolicyAssociation(c.context(ctx), id)
+}
 
-	var policies []*entities.Policy
-	if err := ParseResponse(resp, &policies); err != nil {
-		return nil, err
-	}
+// DeletePolicyAssociation detaches a policy
+func (c *Client) DeletePolicyAssociation(ctx context.Context, id string) error {
+	return c.api.DeletePolicyAssociation(c.context(ctx), id)
+}
 
-	return policies, nil
+// IterPolicyAssociations iterates over all policy associations, fetching them page by page
+func (c *Client) IterPolicyAssociations(ctx context.Context, opts *ListOptions) iter.Seq2[*entities.PolicyAssociation, error] {
+	return c.api.IterPolicyAssociations(c.context(ctx), c.listOptions(opts))
 }
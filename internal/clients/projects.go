@@ -1,78 +1,39 @@
 package clients
 
 import (
-	"fmt"
-	"net/http"
+	"context"
+	"iter"
 
 	"terraform-provider-kubiya-control-plane/internal/entities"
 )
 
 // CreateProject creates a new project
-func (c *Client) CreateProject(req *entities.ProjectCreateRequest) (*entities.Project, error) {
-	resp, err := c.DoRequest(http.MethodPost, "/api/v1/projects", req)
-	if err != nil {
-		return nil, err
-	}
-
-	var project entities.Project
-	if err := ParseResponse(resp, &project); err != nil {
-		return nil, err
-	}
-
-	return &project, nil
+func (c *Client) CreateProject(ctx context.Context, req *entities.ProjectCreateRequest) (*entities.Project, error) {
+	return c.api.CreateProject(c.context(ctx), req)
 }
 
 // GetProject retrieves a project by ID
-func (c *Client) GetProject(id string) (*entities.Project, error) {
-	resp, err := c.DoRequest(http.MethodGet, fmt.Sprintf("/api/v1/projects/%s", id), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var project entities.Project
-	if err := ParseResponse(resp, &project); err != nil {
-		return nil, err
-	}
-
-	return &project, nil
+func (c *Client) GetProject(ctx context.Context, id string) (*entities.Project, error) {
+	return c.api.GetProject(c.context(ctx), id)
 }
 
 // UpdateProject updates an existing project
-func (c *Client) UpdateProject(id string, req *entities.ProjectUpdateRequest) (*entities.Project, error) {
-	resp, err := c.DoRequest(http.MethodPatch, fmt.Sprintf("/api/v1/projects/%s", id), req)
-	if err != nil {
-		return nil, err
-	}
-
-	var project entities.Project
-	if err := ParseResponse(resp, &project); err != nil {
-		return nil, err
-	}
-
-	return &project, nil
+func (c *Client) UpdateProject(ctx context.Context, id string, req *entities.ProjectUpdateRequest) (*entities.Project, error) {
+	c.MarkWritten(id)
+	return c.api.UpdateProject(c.context(ctx), id, req)
 }
 
 // DeleteProject deletes a project
-func (c *Client) DeleteProject(id string) error {
-	resp, err := c.DoRequest(http.MethodDelete, fmt.Sprintf("/api/v1/projects/%s", id), nil)
-	if err != nil {
-		return err
-	}
-
-	return ParseResponse(resp, nil)
+func (c *Client) DeleteProject(ctx context.Context, id string) error {
+	return c.api.DeleteProject(c.context(ctx), id)
 }
 
 // ListProjects lists all projects
-func (c *Client) ListProjects() ([]*entities.Project, error) {
-	resp, err := c.DoRequest(http.MethodGet, "/api/v1/projects", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var projects []*entities.Project
-	if err := ParseResponse(resp, &projects); err != nil {
-		return nil, err
-	}
+func (c *Client) ListProjects(ctx context.Context) ([]*entities.Project, error) {
+	return c.api.ListProjects(c.context(ctx), c.listOptions(nil))
+}
 
-	return projects, nil
+// IterProjects iterates over all projects, fetching them page by page
+func (c *Client) IterProjects(ctx context.Context, opts *ListOptions) iter.Seq2[*entities.Project, error] {
+	return c.api.IterProjects(c.context(ctx), c.listOptions(opts))
 }
@@ -0,0 +1,178 @@
+package clients
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// maxRateLimitRetries is how many times a request rejected with 429 Too Many Requests is retried
+	maxRateLimitRetries = 5
+	// defaultRateLimitBackoff is the wait before retrying a 429 response that carries no reset hint
+	defaultRateLimitBackoff = 2 * time.Second
+	// maxRateLimitWait caps how long a single server-provided reset hint may pause requests
+	maxRateLimitWait = 2 * time.Minute
+)
+
+// RateLimitConfig controls client-side request throttling
+type RateLimitConfig struct {
+	// RequestsPerSecond is the sustained request rate; zero disables client-side rate limiting
+	RequestsPerSecond float64
+	// Burst is the number of requests that may be sent at once before the rate applies; zero uses the
+	// rate rounded up
+	Burst int
+	// MaxConcurrentRequests caps the number of requests in flight; zero means no cap
+	MaxConcurrentRequests int
+}
+
+// rateLimiter is a token bucket combined with a concurrency semaphore. It also pauses all requests
+// when the server reports that the rate limit is exhausted.
+type rateLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	slots       chan struct{}
+}
+
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	burst := float64(config.Burst)
+	if burst <= 0 {
+		burst = math.Max(1, math.Ceil(config.RequestsPerSecond))
+	}
+
+	limiter := &rateLimiter{
+		rate:   config.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+
+	if config.MaxConcurrentRequests > 0 {
+		limiter.slots = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+
+	return limiter
+}
+
+// acquire blocks until a request may be sent and returns a function that releases its slot. It
+// returns the context's error if ctx is done first.
+func (l *rateLimiter) acquire(ctx context.Context) (func(), error) {
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	release := func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+
+	if delay := l.reserve(); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+
+	return release, nil
+}
+
+// reserve takes a token from the bucket and returns how long the caller has to wait before using it
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	var delay time.Duration
+	if l.pausedUntil.After(now) {
+		delay = l.pausedUntil.Sub(now)
+	}
+
+	if l.rate <= 0 {
+		return delay
+	}
+
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+
+	// A negative balance is a debt that is paid off at the configured rate
+	if l.tokens < 0 {
+		delay = max(delay, time.Duration(-l.tokens/l.rate*float64(time.Second)))
+	}
+
+	return delay
+}
+
+// pause holds back every request until the given time
+func (l *rateLimiter) pause(until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// observe adapts to the X-RateLimit-* headers of a response: once the remaining quota reaches zero,
+// requests are paused until the reported reset time
+func (l *rateLimiter) observe(resp *http.Response) {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil || remaining > 0 {
+		return
+	}
+
+	if reset, ok := rateLimitReset(resp.Header.Get("X-RateLimit-Reset")); ok {
+		l.pause(time.Now().Add(reset))
+	}
+}
+
+// retryDelay returns how long to wait before retrying a 429 response, from Retry-After or
+// X-RateLimit-Reset, falling back to a fixed backoff that grows with each attempt
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return min(time.Duration(seconds)*time.Second, maxRateLimitWait)
+	}
+
+	if date, err := http.ParseTime(resp.Header.Get("Retry-After")); err == nil {
+		return min(max(time.Until(date), 0), maxRateLimitWait)
+	}
+
+	if reset, ok := rateLimitReset(resp.Header.Get("X-RateLimit-Reset")); ok {
+		return reset
+	}
+
+	return defaultRateLimitBackoff * time.Duration(attempt+1)
+}
+
+// rateLimitReset parses X-RateLimit-Reset, which servers send either as seconds until the reset or
+// as a Unix timestamp, into the time left until the reset
+func rateLimitReset(value string) (time.Duration, bool) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	// Values this large can only be Unix timestamps
+	if seconds > 1_000_000_000 {
+		return min(max(time.Until(time.Unix(seconds, 0)), 0), maxRateLimitWait), true
+	}
+
+	return min(time.Duration(seconds)*time.Second, maxRateLimitWait), true
+}
@@ -0,0 +1,55 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitRetryStopsWhenCanceled(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("KUBIYA_CONTROL_PLANE_BASE_URL", server.URL)
+	client, err := New("test-api-key")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = client.GetAgent(ctx, "a1")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 10*time.Second, "the retry must not wait out Retry-After")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRateLimiterAcquireCanceled(t *testing.T) {
+	limiter := newRateLimiter(RateLimitConfig{MaxConcurrentRequests: 1})
+
+	release, err := limiter.acquire(context.Background())
+	require.NoError(t, err)
+
+	// The only slot is taken
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = limiter.acquire(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	release()
+	release, err = limiter.acquire(context.Background())
+	require.NoError(t, err)
+	release()
+}
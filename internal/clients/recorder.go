@@ -0,0 +1,421 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// RecorderMode selects whether a Recorder captures traffic or serves it from cassettes
+type RecorderMode string
+
+const (
+	// RecorderOff sends requests to the API unchanged
+	RecorderOff RecorderMode = ""
+	// RecorderRecord sends requests to the API and writes every interaction to a cassette
+	RecorderRecord RecorderMode = "record"
+	// RecorderReplay serves responses from cassettes and never touches the network
+	RecorderReplay RecorderMode = "replay"
+)
+
+// redacted replaces secrets in recorded cassettes
+const redacted = "REDACTED"
+
+// sensitiveHeaders are dropped from recorded requests and responses
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// sensitiveFields are JSON keys whose values are redacted in recorded bodies. A key matches when it
+// is one of them or ends with one after an underscore, ignoring case, so worker_token and apiKey are
+// redacted but max_tokens and token_count are not.
+var sensitiveFields = []string{"token", "secret", "password", "api_key", "apikey"}
+
+// Cassette is the sanitized traffic of one provider process
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response the API sent for it
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the sanitized part of a request kept in a cassette
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URI    string      `json:"uri"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the sanitized part of a response kept in a cassette
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records API traffic to cassettes or replays it from them.
+//
+// Terraform starts a new provider process for most commands, so a Recorder writes one cassette per
+// process into its directory, numbered in the order the processes first talk to the API. In replay
+// mode each process claims the next unreplayed cassette the same way. Requests are matched on method
+// and URI, and on the sanitized body where that tells the recorded requests apart. Identical requests
+// are answered with their recorded responses in order, and once those run out the last one is
+// repeated, which keeps polling loops deterministic.
+type Recorder struct {
+	mode RecorderMode
+	dir  string
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	path     string
+	cassette *Cassette
+	served   map[string]int
+}
+
+// NewRecorder returns a Recorder that stores cassettes in dir. In record mode requests are sent
+// through next, or http.DefaultTransport when next is nil.
+func NewRecorder(mode RecorderMode, dir string, next http.RoundTripper) (*Recorder, error) {
+	if mode != RecorderRecord && mode != RecorderReplay {
+		return nil, fmt.Errorf("unknown recorder mode %q, expected %q or %q", mode, RecorderRecord, RecorderReplay)
+	}
+
+	if dir == "" {
+		return nil, fmt.Errorf("cassette directory is required in %s mode", mode)
+	}
+
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Recorder{mode: mode, dir: dir, next: next, served: make(map[string]int)}, nil
+}
+
+// getRecorderConfig returns the recorder mode and cassette directory
+// Default: recorder off
+// Enable with KUBIYA_CONTROL_PLANE_RECORDER_MODE=record|replay and KUBIYA_CONTROL_PLANE_CASSETTE_DIR
+func getRecorderConfig() (RecorderMode, string) {
+	return RecorderMode(os.Getenv("KUBIYA_CONTROL_PLANE_RECORDER_MODE")), os.Getenv("KUBIYA_CONTROL_PLANE_CASSETTE_DIR")
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == RecorderReplay {
+		return r.replay(req)
+	}
+
+	return r.record(req)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URI:    req.URL.RequestURI(),
+			Header: sanitizeHeader(req.Header),
+			Body:   sanitizeBody(requestBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     sanitizeHeader(resp.Header),
+			Body:       sanitizeBody(responseBody),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cassette == nil {
+		path, err := claimCassette(r.dir, func(path string) (*os.File, error) {
+			return os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		})
+		if err != nil {
+			return nil, err
+		}
+		r.path = path
+		r.cassette = &Cassette{}
+	}
+
+	// The provider process can be stopped at any time, so the cassette is rewritten after every request
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	if err := writeCassette(r.path, r.cassette); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cassette == nil {
+		path, err := claimCassette(r.dir, func(path string) (*os.File, error) {
+			if _, err := os.Stat(path); err != nil {
+				return nil, fmt.Errorf("no cassette left to replay in %s: %w", r.dir, err)
+			}
+			return os.OpenFile(replayMarker(path), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		cassette, err := readCassette(path)
+		if err != nil {
+			return nil, err
+		}
+		r.path = path
+		r.cassette = cassette
+	}
+
+	uri := req.URL.RequestURI()
+	key := req.Method + " " + uri
+
+	var matches []*Interaction
+	for i := range r.cassette.Interactions {
+		interaction := &r.cassette.Interactions[i]
+		if interaction.Request.Method == req.Method && interaction.Request.URI == uri {
+			matches = append(matches, interaction)
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no recorded interaction for %s in %s", key, r.path)
+	}
+
+	// Terraform changes independent resources concurrently, so their requests to the same URI can
+	// arrive in any order; the body tells them apart
+	body := sanitizeBody(requestBody)
+	var sameBody []*Interaction
+	for _, interaction := range matches {
+		if interaction.Request.Body == body {
+			sameBody = append(sameBody, interaction)
+		}
+	}
+	if len(sameBody) > 0 && len(sameBody) < len(matches) {
+		matches = sameBody
+		key += " " + body
+	}
+
+	interaction := matches[min(r.served[key], len(matches)-1)]
+	r.served[key]++
+
+	return &http.Response{
+		StatusCode:    interaction.Response.StatusCode,
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        interaction.Response.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// claimCassette returns the first cassette path in dir that open can claim exclusively. open reports
+// fs.ErrExist for a path that another process has already claimed.
+func claimCassette(dir string, open func(path string) (*os.File, error)) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+
+	for i := 1; ; i++ {
+		path := filepath.Join(dir, fmt.Sprintf("%03d.json", i))
+		f, err := open(path)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to claim cassette: %w", err)
+		}
+		_ = f.Close()
+		return path, nil
+	}
+}
+
+// replayMarker is the file that marks a cassette as replayed by a provider process
+func replayMarker(path string) string {
+	return filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".replayed")
+}
+
+// ResetReplay forgets which cassettes in dir were replayed, so the next replay starts from the first one
+func ResetReplay(dir string) error {
+	markers, err := filepath.Glob(filepath.Join(dir, ".*.replayed"))
+	if err != nil {
+		return err
+	}
+
+	for _, marker := range markers {
+		if err := os.Remove(marker); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+
+	return &cassette, nil
+}
+
+func writeCassette(path string, cassette *Cassette) error {
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	return nil
+}
+
+// sanitizeHeader copies a header without credentials, tracing headers and request IDs, which differ on every run.
+// Content-Length is dropped as well because redaction can change the size of the body.
+func sanitizeHeader(header http.Header) http.Header {
+	sanitized := header.Clone()
+	for _, name := range sensitiveHeaders {
+		sanitized.Del(name)
+	}
+	sanitized.Del("Sentry-Trace")
+	sanitized.Del("Baggage")
+	sanitized.Del("X-Request-ID")
+	sanitized.Del("Content-Length")
+
+	if len(sanitized) == 0 {
+		return nil
+	}
+
+	return sanitized
+}
+
+// sanitizeBody redacts the values of sensitive fields in a JSON body; other bodies are kept as they are
+func sanitizeBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	// UseNumber keeps large integers exact through the round trip
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return string(body)
+	}
+
+	sanitized, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(sanitized)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitiveField(key) {
+				if _, ok := field.(string); ok {
+					v[key] = redacted
+					continue
+				}
+			}
+			v[key] = redactValue(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+
+	return value
+}
+
+func isSensitiveField(key string) bool {
+	key = snakeCase(key)
+	for _, field := range sensitiveFields {
+		if key == field || strings.HasSuffix(key, "_"+field) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// snakeCase lowercases a JSON key and separates its words with underscores, so apiKey, api-key and
+// API_KEY all become api_key
+func snakeCase(key string) string {
+	var b strings.Builder
+	for i, r := range key {
+		switch {
+		case r == '-':
+			b.WriteByte('_')
+		case unicode.IsUpper(r):
+			if i > 0 && !unicode.IsUpper(rune(key[i-1])) && key[i-1] != '_' && key[i-1] != '-' {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
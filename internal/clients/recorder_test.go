@@ -0,0 +1,215 @@
+package clients
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestCassette writes the interactions as the first cassette in dir
+func writeTestCassette(t *testing.T, dir string, interactions ...Interaction) {
+	t.Helper()
+
+	require.NoError(t, writeCassette(filepath.Join(dir, "001.json"), &Cassette{Interactions: interactions}))
+}
+
+func interaction(method, uri string, statusCode int, body string) Interaction {
+	return Interaction{
+		Request:  RecordedRequest{Method: method, URI: uri},
+		Response: RecordedResponse{StatusCode: statusCode, Body: body},
+	}
+}
+
+// roundTrip sends a request through the recorder and returns the response status and body
+func roundTrip(t *testing.T, recorder http.RoundTripper, method, url string, body string) (int, string) {
+	t.Helper()
+
+	var reqBody io.Reader
+	if body != "" {
+		reqBody = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, reqBody)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret-api-key")
+
+	resp, err := recorder.RoundTrip(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(respBody)
+}
+
+func TestRecorderReplayMatching(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCassette(t, dir,
+		interaction(http.MethodPost, "/api/v1/agents", http.StatusCreated, `{"id":"a1","status":"pending"}`),
+		interaction(http.MethodGet, "/api/v1/agents/a1", http.StatusOK, `{"id":"a1","status":"pending"}`),
+		interaction(http.MethodGet, "/api/v1/agents/a1", http.StatusOK, `{"id":"a1","status":"idle"}`),
+		interaction(http.MethodGet, "/api/v1/agents?skip=0&limit=100", http.StatusOK, `[]`),
+	)
+
+	// The cassette also has requests that Terraform sent concurrently
+	cassette, err := readCassette(filepath.Join(dir, "001.json"))
+	require.NoError(t, err)
+	for _, name := range []string{"first", "second"} {
+		recorded := interaction(http.MethodPost, "/api/v1/teams", http.StatusCreated, `{"id":"`+name+`"}`)
+		recorded.Request.Body = `{"name":"` + name + `"}`
+		cassette.Interactions = append(cassette.Interactions, recorded)
+	}
+	require.NoError(t, writeCassette(filepath.Join(dir, "001.json"), cassette))
+
+	recorder, err := NewRecorder(RecorderReplay, dir, nil)
+	require.NoError(t, err)
+
+	// The host is not part of the match, and neither is the body when it tells no requests apart
+	status, body := roundTrip(t, recorder, http.MethodPost, "https://control-plane.example.com/api/v1/agents", `{"name":"other"}`)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, `{"id":"a1","status":"pending"}`, body)
+
+	// Identical requests get their responses in order, then the last one again
+	for _, want := range []string{"pending", "idle", "idle"} {
+		_, body = roundTrip(t, recorder, http.MethodGet, "http://localhost/api/v1/agents/a1", "")
+		assert.Contains(t, body, want)
+	}
+
+	// Requests that differ only in their body get the response recorded for that body, in any order
+	_, body = roundTrip(t, recorder, http.MethodPost, "http://localhost/api/v1/teams", `{"name": "second"}`)
+	assert.Equal(t, `{"id":"second"}`, body)
+	_, body = roundTrip(t, recorder, http.MethodPost, "http://localhost/api/v1/teams", `{"name": "first"}`)
+	assert.Equal(t, `{"id":"first"}`, body)
+
+	// The query string is part of the URI
+	_, body = roundTrip(t, recorder, http.MethodGet, "http://localhost/api/v1/agents?skip=0&limit=100", "")
+	assert.Equal(t, `[]`, body)
+
+	for _, req := range []struct{ method, url string }{
+		{http.MethodGet, "http://localhost/api/v1/agents?skip=100&limit=100"},
+		{http.MethodDelete, "http://localhost/api/v1/agents/a1"},
+	} {
+		r, err := http.NewRequest(req.method, req.url, nil)
+		require.NoError(t, err)
+		_, err = recorder.RoundTrip(r)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no recorded interaction for "+req.method)
+	}
+}
+
+func TestRecorderReplayClaimsCassettesInOrder(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCassette(t, dir, interaction(http.MethodGet, "/api/v1/teams", http.StatusOK, `["first"]`))
+	require.NoError(t, writeCassette(filepath.Join(dir, "002.json"), &Cassette{Interactions: []Interaction{
+		interaction(http.MethodGet, "/api/v1/teams", http.StatusOK, `["second"]`),
+	}}))
+
+	// Every recorder stands for one provider process
+	for _, want := range []string{`["first"]`, `["second"]`} {
+		recorder, err := NewRecorder(RecorderReplay, dir, nil)
+		require.NoError(t, err)
+		_, body := roundTrip(t, recorder, http.MethodGet, "http://localhost/api/v1/teams", "")
+		assert.Equal(t, want, body)
+	}
+
+	recorder, err := NewRecorder(RecorderReplay, dir, nil)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/api/v1/teams", nil)
+	require.NoError(t, err)
+	_, err = recorder.RoundTrip(req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no cassette left to replay")
+
+	// Resetting starts again from the first cassette
+	require.NoError(t, ResetReplay(dir))
+	recorder, err = NewRecorder(RecorderReplay, dir, nil)
+	require.NoError(t, err)
+	_, body := roundTrip(t, recorder, http.MethodGet, "http://localhost/api/v1/teams", "")
+	assert.Equal(t, `["first"]`, body)
+}
+
+func TestRecorderRecordSanitizes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc123")
+		w.Header().Set("X-Request-ID", "server-request-id")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"id":"q1","auth":{"worker_token":"wt-123","ttl":3600},"items":[{"client_secret":"cs-456"}]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(RecorderRecord, dir, nil)
+	require.NoError(t, err)
+
+	status, body := roundTrip(t, recorder, http.MethodPost, server.URL+"/api/v1/worker-queues", `{"name":"gpu","api_key":"key-789","password":"hunter2"}`)
+
+	// The caller still gets the response as the API sent it
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Contains(t, body, "wt-123")
+
+	cassette, err := readCassette(filepath.Join(dir, "001.json"))
+	require.NoError(t, err)
+	require.Len(t, cassette.Interactions, 1)
+	recorded := cassette.Interactions[0]
+
+	assert.Equal(t, http.MethodPost, recorded.Request.Method)
+	assert.Equal(t, "/api/v1/worker-queues", recorded.Request.URI)
+	assert.Empty(t, recorded.Request.Header.Get("Authorization"))
+	assert.JSONEq(t, `{"name":"gpu","api_key":"REDACTED","password":"REDACTED"}`, recorded.Request.Body)
+
+	assert.Equal(t, http.StatusCreated, recorded.Response.StatusCode)
+	assert.Empty(t, recorded.Response.Header.Get("Set-Cookie"))
+	assert.Empty(t, recorded.Response.Header.Get("X-Request-ID"))
+	assert.Equal(t, "application/json", recorded.Response.Header.Get("Content-Type"))
+	assert.JSONEq(t, `{"id":"q1","auth":{"worker_token":"REDACTED","ttl":3600},"items":[{"client_secret":"REDACTED"}]}`, recorded.Response.Body)
+
+	// Nothing secret is left anywhere in the file
+	data, err := os.ReadFile(filepath.Join(dir, "001.json"))
+	require.NoError(t, err)
+	for _, secret := range []string{"secret-api-key", "abc123", "wt-123", "cs-456", "key-789", "hunter2"} {
+		assert.NotContains(t, string(data), secret)
+	}
+}
+
+func TestSanitizeHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer secret")
+	header.Set("Cookie", "session=abc")
+	header.Set("X-Api-Key", "secret")
+	header.Set("Sentry-Trace", "trace")
+	header.Set("Baggage", "sentry-trace_id=1")
+	header.Set("X-Request-ID", "id")
+	header.Set("Content-Length", "42")
+	header.Set("Accept", "application/json")
+
+	assert.Equal(t, http.Header{"Accept": {"application/json"}}, sanitizeHeader(header))
+	assert.Equal(t, "Bearer secret", header.Get("Authorization"), "the original header is left unchanged")
+
+	header.Del("Accept")
+	assert.Nil(t, sanitizeHeader(header))
+}
+
+func TestSanitizeBody(t *testing.T) {
+	tests := map[string]string{
+		// Only string values of sensitive keys are redacted; numbers are kept exact
+		`{"Token":"t","token_count":3,"id":12345678901234567890}`: `{"Token":"REDACTED","id":12345678901234567890,"token_count":3}`,
+		`[{"apiKey":"k"},{"name":"n"}]`:                           `[{"apiKey":"REDACTED"},{"name":"n"}]`,
+		`{"settings":{"db_password":"p","region":"eu"}}`:          `{"settings":{"db_password":"REDACTED","region":"eu"}}`,
+		// Keys are matched as whole words, not substrings
+		`{"max_tokens":"4096","prompt_tokens":"12","secret_name":"s","workerToken":"t"}`: `{"max_tokens":"4096","prompt_tokens":"12","secret_name":"s","workerToken":"REDACTED"}`,
+		`not json`: `not json`,
+		``:         ``,
+	}
+
+	for body, want := range tests {
+		assert.Equal(t, want, sanitizeBody([]byte(body)), "body %s", body)
+	}
+}
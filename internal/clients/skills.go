@@ -1,78 +1,66 @@
 package clients
 
 import (
-	"fmt"
-	"net/http"
+	"context"
+	"iter"
+	"sync"
 
 	"terraform-provider-kubiya-control-plane/internal/entities"
 )
 
-// CreateSkill creates a new skill (uses /api/v1/skills endpoint)
-func (c *Client) CreateSkill(req *entities.SkillCreateRequest) (*entities.Skill, error) {
-	resp, err := c.DoRequest(http.MethodPost, "/api/v1/skills", req)
-	if err != nil {
-		return nil, err
-	}
-
-	var skill entities.Skill
-	if err := ParseResponse(resp, &skill); err != nil {
-		return nil, err
-	}
-
-	return &skill, nil
+// CreateSkill creates a new skill
+func (c *Client) CreateSkill(ctx context.Context, req *entities.SkillCreateRequest) (*entities.Skill, error) {
+	return c.api.CreateSkill(c.context(ctx), req)
 }
 
-// GetSkill retrieves a skill by ID (uses /api/v1/skills endpoint)
-func (c *Client) GetSkill(id string) (*entities.Skill, error) {
-	resp, err := c.DoRequest(http.MethodGet, fmt.Sprintf("/api/v1/skills/%s", id), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var skill entities.Skill
-	if err := ParseResponse(resp, &skill); err != nil {
-		return nil, err
-	}
-
-	return &skill, nil
+// GetSkill retrieves a skill by ID
+func (c *Client) GetSkill(ctx context.Context, id string) (*entities.Skill, error) {
+	return c.api.GetSkill(c.context(ctx), id)
 }
 
-// UpdateSkill updates an existing skill (uses /api/v1/skills endpoint)
-func (c *Client) UpdateSkill(id string, req *entities.SkillUpdateRequest) (*entities.Skill, error) {
-	resp, err := c.DoRequest(http.MethodPatch, fmt.Sprintf("/api/v1/skills/%s", id), req)
-	if err != nil {
-		return nil, err
-	}
+// UpdateSkill updates an existing skill
+func (c *Client) UpdateSkill(ctx context.Context, id string, req *entities.SkillUpdateRequest) (*entities.Skill, error) {
+	c.MarkWritten(id)
+	return c.api.UpdateSkill(c.context(ctx), id, req)
+}
 
-	var skill entities.Skill
-	if err := ParseResponse(resp, &skill); err != nil {
-		return nil, err
-	}
+// DeleteSkill deletes a skill
+func (c *Client) DeleteSkill(ctx context.Context, id string) error {
+	return c.api.DeleteSkill(c.context(ctx), id)
+}
 
-	return &skill, nil
+// ListSkills lists all skills
+func (c *Client) ListSkills(ctx context.Context) ([]*entities.Skill, error) {
+	return c.api.ListSkills(c.context(ctx), c.listOptions(nil))
 }
 
-// DeleteSkill deletes a skill (uses /api/v1/skills endpoint)
-func (c *Client) DeleteSkill(id string) error {
-	resp, err := c.DoRequest(http.MethodDelete, fmt.Sprintf("/api/v1/skills/%s", id), nil)
-	if err != nil {
-		return err
-	}
+// IterSkills iterates over all skills, fetching them page by page
+func (c *Client) IterSkills(ctx context.Context, opts *ListOptions) iter.Seq2[*entities.Skill, error] {
+	return c.api.IterSkills(c.context(ctx), c.listOptions(opts))
+}
 
-	return ParseResponse(resp, nil)
+// skillDefinitions memoizes the skill definitions, which every skill resource checks its plan against
+type skillDefinitions struct {
+	mu          sync.Mutex
+	definitions []*entities.SkillDefinition
 }
 
-// ListSkills lists all skills (uses /api/v1/skills endpoint)
-func (c *Client) ListSkills() ([]*entities.Skill, error) {
-	resp, err := c.DoRequest(http.MethodGet, "/api/v1/skills", nil)
-	if err != nil {
-		return nil, err
+// ListSkillDefinitions lists every skill type the control plane supports, including custom types. The
+// first successful result is kept for the lifetime of the client, so a plan with many skills fetches
+// the definitions once; concurrent callers wait for the request in flight.
+func (c *Client) ListSkillDefinitions(ctx context.Context) ([]*entities.SkillDefinition, error) {
+	c.skillDefinitions.mu.Lock()
+	defer c.skillDefinitions.mu.Unlock()
+
+	if c.skillDefinitions.definitions != nil {
+		return c.skillDefinitions.definitions, nil
 	}
 
-	var skills []*entities.Skill
-	if err := ParseResponse(resp, &skills); err != nil {
+	definitions, err := c.api.ListSkillDefinitions(c.context(ctx), c.listOptions(nil))
+	if err != nil {
 		return nil, err
 	}
+	c.skillDefinitions.definitions = definitions
 
-	return skills, nil
+	return definitions, nil
 }
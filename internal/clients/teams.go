@@ -1,78 +1,57 @@
 package clients
 
 import (
-	"fmt"
-	"net/http"
+	"context"
+	"iter"
 
 	"terraform-provider-kubiya-control-plane/internal/entities"
 )
 
 // CreateTeam creates a new team
-func (c *Client) CreateTeam(req *entities.TeamCreateRequest) (*entities.Team, error) {
-	resp, err := c.DoRequest(http.MethodPost, "/api/v1/teams", req)
-	if err != nil {
-		return nil, err
-	}
-
-	var team entities.Team
-	if err := ParseResponse(resp, &team); err != nil {
-		return nil, err
-	}
-
-	return &team, nil
+func (c *Client) CreateTeam(ctx context.Context, req *entities.TeamCreateRequest) (*entities.Team, error) {
+	return c.api.CreateTeam(c.context(ctx), req)
 }
 
 // GetTeam retrieves a team by ID
-func (c *Client) GetTeam(id string) (*entities.Team, error) {
-	resp, err := c.DoRequest(http.MethodGet, fmt.Sprintf("/api/v1/teams/%s", id), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var team entities.Team
-	if err := ParseResponse(resp, &team); err != nil {
-		return nil, err
-	}
-
-	return &team, nil
+func (c *Client) GetTeam(ctx context.Context, id string) (*entities.Team, error) {
+	return c.api.GetTeam(c.context(ctx), id)
 }
 
 // UpdateTeam updates an existing team
-func (c *Client) UpdateTeam(id string, req *entities.TeamUpdateRequest) (*entities.Team, error) {
-	resp, err := c.DoRequest(http.MethodPatch, fmt.Sprintf("/api/v1/teams/%s", id), req)
-	if err != nil {
-		return nil, err
-	}
-
-	var team entities.Team
-	if err := ParseResponse(resp, &team); err != nil {
-		return nil, err
-	}
-
-	return &team, nil
+func (c *Client) UpdateTeam(ctx context.Context, id string, req *entities.TeamUpdateRequest) (*entities.Team, error) {
+	c.MarkWritten(id)
+	return c.api.UpdateTeam(c.context(ctx), id, req)
 }
 
 // DeleteTeam deletes a team
-func (c *Client) DeleteTeam(id string) error {
-	resp, err := c.DoRequest(http.MethodDelete, fmt.Sprintf("/api/v1/teams/%s", id), nil)
-	if err != nil {
-		return err
-	}
-
-	return ParseResponse(resp, nil)
+func (c *Client) DeleteTeam(ctx context.Context, id string) error {
+	return c.api.DeleteTeam(c.context(ctx), id)
 }
 
 // ListTeams lists all teams
-func (c *Client) ListTeams() ([]*entities.Team, error) {
-	resp, err := c.DoRequest(http.MethodGet, "/api/v1/teams", nil)
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) ListTeams(ctx context.Context) ([]*entities.Team, error) {
+	return c.api.ListTeams(c.context(ctx), c.listOptions(nil))
+}
+
+// IterTeams iterates over all teams, fetching them page by page
+func (c *Client) IterTeams(ctx context.Context, opts *ListOptions) iter.Seq2[*entities.Team, error] {
+	return c.api.IterTeams(c.context(ctx), c.listOptions(opts))
+}
 
-	var teams []*entities.Team
-	if err := ParseResponse(resp, &teams); err != nil {
-		return nil, err
-	}
+// AddTeamMember adds an agent to a team
+func (c *Client) AddTeamMember(ctx context.Context, teamID, agentID string, req *entities.TeamMemberRequest) (*entities.TeamMember, error) {
+	c.MarkWritten(teamID, agentID)
+	return c.api.AddTeamMember(c.context(ctx), teamID, agentID, req)
+}
+
+// UpdateTeamMember changes the role of an agent in its team
+func (c *Client) UpdateTeamMember(ctx context.Context, teamID, agentID string, req *entities.TeamMemberRequest) (*entities.TeamMember, error) {
+	c.MarkWritten(teamID, agentID)
+	return c.api.UpdateTeamMember(c.context(ctx), teamID, agentID, req)
+}
 
-	return teams, nil
+// RemoveTeamMember removes an agent from a team
+func (c *Client) RemoveTeamMember(ctx context.Context, teamID, agentID string) error {
+	c.MarkWritten(teamID, agentID)
+	return c.api.RemoveTeamMember(c.context(ctx), teamID, agentID)
 }
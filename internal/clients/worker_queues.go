@@ -1,78 +1,39 @@
 package clients
 
 import (
-	"fmt"
-	"net/http"
+	"context"
+	"iter"
 
 	"terraform-provider-kubiya-control-plane/internal/entities"
 )
 
 // CreateWorkerQueue creates a new worker queue
-func (c *Client) CreateWorkerQueue(environmentID string, req *entities.WorkerQueueCreateRequest) (*entities.WorkerQueue, error) {
-	resp, err := c.DoRequest(http.MethodPost, fmt.Sprintf("/api/v1/environments/%s/worker-queues", environmentID), req)
-	if err != nil {
-		return nil, err
-	}
-
-	var queue entities.WorkerQueue
-	if err := ParseResponse(resp, &queue); err != nil {
-		return nil, err
-	}
-
-	return &queue, nil
+func (c *Client) CreateWorkerQueue(ctx context.Context, environmentID string, req *entities.WorkerQueueCreateRequest) (*entities.WorkerQueue, error) {
+	return c.api.CreateWorkerQueue(c.context(ctx), environmentID, req)
 }
 
 // GetWorkerQueue retrieves a worker queue by ID
-func (c *Client) GetWorkerQueue(queueID string) (*entities.WorkerQueue, error) {
-	resp, err := c.DoRequest(http.MethodGet, fmt.Sprintf("/api/v1/worker-queues/%s", queueID), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var queue entities.WorkerQueue
-	if err := ParseResponse(resp, &queue); err != nil {
-		return nil, err
-	}
-
-	return &queue, nil
+func (c *Client) GetWorkerQueue(ctx context.Context, queueID string) (*entities.WorkerQueue, error) {
+	return c.api.GetWorkerQueue(c.context(ctx), queueID)
 }
 
-// UpdateWorkerQueue updates a worker queue
-func (c *Client) UpdateWorkerQueue(queueID string, req *entities.WorkerQueueUpdateRequest) (*entities.WorkerQueue, error) {
-	resp, err := c.DoRequest(http.MethodPatch, fmt.Sprintf("/api/v1/worker-queues/%s", queueID), req)
-	if err != nil {
-		return nil, err
-	}
-
-	var queue entities.WorkerQueue
-	if err := ParseResponse(resp, &queue); err != nil {
-		return nil, err
-	}
-
-	return &queue, nil
+// UpdateWorkerQueue updates an existing worker queue
+func (c *Client) UpdateWorkerQueue(ctx context.Context, queueID string, req *entities.WorkerQueueUpdateRequest) (*entities.WorkerQueue, error) {
+	c.MarkWritten(queueID)
+	return c.api.UpdateWorkerQueue(c.context(ctx), queueID, req)
 }
 
 // DeleteWorkerQueue deletes a worker queue
-func (c *Client) DeleteWorkerQueue(queueID string) error {
-	resp, err := c.DoRequest(http.MethodDelete, fmt.Sprintf("/api/v1/worker-queues/%s", queueID), nil)
-	if err != nil {
-		return err
-	}
-
-	return ParseResponse(resp, nil)
+func (c *Client) DeleteWorkerQueue(ctx context.Context, queueID string) error {
+	return c.api.DeleteWorkerQueue(c.context(ctx), queueID)
 }
 
 // ListWorkerQueues lists all worker queues in an environment
-func (c *Client) ListWorkerQueues(environmentID string) ([]*entities.WorkerQueue, error) {
-	resp, err := c.DoRequest(http.MethodGet, fmt.Sprintf("/api/v1/environments/%s/worker-queues", environmentID), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var queues []*entities.WorkerQueue
-	if err := ParseResponse(resp, &queues); err != nil {
-		return nil, err
-	}
+func (c *Client) ListWorkerQueues(ctx context.Context, environmentID string) ([]*entities.WorkerQueue, error) {
+	return c.api.ListWorkerQueues(c.context(ctx), environmentID, c.listOptions(nil))
+}
 
-	return queues, nil
+// IterWorkerQueues iterates over all worker queues in an environment, fetching them page by page
+func (c *Client) IterWorkerQueues(ctx context.Context, environmentID string, opts *ListOptions) iter.Seq2[*entities.WorkerQueue, error] {
+	return c.api.IterWorkerQueues(c.context(ctx), environmentID, c.listOptions(opts))
 }
@@ -0,0 +1,34 @@
+package clients
+
+import "sync"
+
+// writtenObjects remembers the IDs of the objects a client changed, directly or as a side effect of
+// another write, e.g. an agent that joined a team through AddTeamMember
+type writtenObjects struct {
+	mu  sync.Mutex
+	ids map[string]bool
+}
+
+// MarkWritten records that this client changed the objects with the given IDs. Writes made through
+// the client mark their objects themselves, before the request is sent, since a write that fails may
+// still have been applied. Callers only mark side effects the client cannot see, such as the target of
+// a policy association being deleted.
+func (c *Client) MarkWritten(ids ...string) {
+	c.written.mu.Lock()
+	defer c.written.mu.Unlock()
+
+	for _, id := range ids {
+		if id != "" {
+			c.written.ids[id] = true
+		}
+	}
+}
+
+// Wrote reports whether this client changed the object with the given ID. Its updated_at then moved
+// with the provider's own writes, so it no longer tells whether someone else changed the object.
+func (c *Client) Wrote(id string) bool {
+	c.written.mu.Lock()
+	defer c.written.mu.Unlock()
+
+	return c.written.ids[id]
+}
@@ -0,0 +1,48 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/internal/entities"
+)
+
+func TestWritesMarkObjects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("KUBIYA_CONTROL_PLANE_BASE_URL", server.URL)
+	client, err := New("test-api-key")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.GetAgent(ctx, "a1")
+	require.NoError(t, err)
+	assert.False(t, client.Wrote("a1"), "reads do not mark objects")
+
+	_, err = client.UpdateAgent(ctx, "a1", &entities.AgentUpdateRequest{})
+	require.NoError(t, err)
+	assert.True(t, client.Wrote("a1"))
+
+	// A membership change also changes the agent
+	require.NoError(t, client.RemoveTeamMember(ctx, "t1", "a2"))
+	assert.True(t, client.Wrote("t1"))
+	assert.True(t, client.Wrote("a2"))
+
+	_, err = client.CreatePolicyAssociation(ctx, &entities.PolicyAssociationCreateRequest{PolicyID: "p1", EntityID: "e1"})
+	require.NoError(t, err)
+	assert.True(t, client.Wrote("p1"))
+	assert.True(t, client.Wrote("e1"))
+
+	// Copies of the client share what was written
+	assert.True(t, client.Uncached().Wrote("a1"))
+	assert.False(t, client.Wrote("a3"))
+}
@@ -0,0 +1,3 @@
+// Package entities aliases the API types of the public SDK in pkg/controlplane, so that provider
+// code keeps using one import path for data models while the SDK owns their definitions.
+package entities
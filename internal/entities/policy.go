@@ -1,45 +1,25 @@
 package entities
 
-import "time"
+import "terraform-provider-kubiya-control-plane/pkg/controlplane"
 
-// PolicyType represents the type of policy
-type PolicyType string
+type (
+	PolicyType          = controlplane.PolicyType
+	Policy              = controlplane.Policy
+	PolicyCreateRequest = controlplane.PolicyCreateRequest
+	PolicyUpdateRequest = controlplane.PolicyUpdateRequest
+	PolicyVersion       = controlplane.PolicyVersion
 
-const (
-	PolicyTypeRego PolicyType = "rego"
-	PolicyTypeJSON PolicyType = "json"
+	PolicyEntityType               = controlplane.PolicyEntityType
+	PolicyAssociation              = controlplane.PolicyAssociation
+	PolicyAssociationCreateRequest = controlplane.PolicyAssociationCreateRequest
 )
 
-// Policy represents an OPA policy in the control plane
-type Policy struct {
-	ID             string     `json:"id,omitempty"`
-	OrganizationID string     `json:"organization_id,omitempty"`
-	Name           string     `json:"name"`
-	Description    *string    `json:"description,omitempty"`
-	PolicyContent  string     `json:"policy_content"`
-	PolicyType     PolicyType `json:"policy_type,omitempty"`
-	Enabled        bool       `json:"enabled"`
-	Tags           []string   `json:"tags,omitempty"`
-	Version        int64      `json:"version,omitempty"`
-	CreatedAt      *time.Time `json:"created_at,omitempty"`
-	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
-}
-
-// PolicyCreateRequest represents the request to create a policy
-type PolicyCreateRequest struct {
-	Name          string     `json:"name"`
-	Description   *string    `json:"description,omitempty"`
-	PolicyContent string     `json:"policy_content"`
-	PolicyType    PolicyType `json:"policy_type,omitempty"`
-	Enabled       bool       `json:"enabled"`
-	Tags          []string   `json:"tags,omitempty"`
-}
+const (
+	PolicyTypeRego = controlplane.PolicyTypeRego
+	PolicyTypeJSON = controlplane.PolicyTypeJSON
 
-// PolicyUpdateRequest represents the request to update a policy
-type PolicyUpdateRequest struct {
-	Name          *string  `json:"name,omitempty"`
-	Description   *string  `json:"description,omitempty"`
-	PolicyContent *string  `json:"policy_content,omitempty"`
-	Enabled       *bool    `json:"enabled,omitempty"`
-	Tags          []string `json:"tags,omitempty"`
-}
+	PolicyEntityAgent       = controlplane.PolicyEntityAgent
+	PolicyEntityTeam        = controlplane.PolicyEntityTeam
+	PolicyEntityEnvironment = controlplane.PolicyEntityEnvironment
+	PolicyEntityProject     = controlplane.PolicyEntityProject
+)
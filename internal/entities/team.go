@@ -1,48 +1,22 @@
 package entities
 
-import "time"
+import "terraform-provider-kubiya-control-plane/pkg/controlplane"
 
-// TeamStatus represents the status of a team
-type TeamStatus string
-
-const (
-	TeamStatusActive   TeamStatus = "active"
-	TeamStatusInactive TeamStatus = "inactive"
-	TeamStatusArchived TeamStatus = "archived"
+type (
+	TeamStatus        = controlplane.TeamStatus
+	Team              = controlplane.Team
+	TeamCreateRequest = controlplane.TeamCreateRequest
+	TeamUpdateRequest = controlplane.TeamUpdateRequest
+	TeamRole          = controlplane.TeamRole
+	TeamMember        = controlplane.TeamMember
+	TeamMemberRequest = controlplane.TeamMemberRequest
 )
 
-// Team represents a team in the control plane
-type Team struct {
-	ID                   string                 `json:"id,omitempty"`
-	OrganizationID       string                 `json:"organization_id,omitempty"`
-	Name                 string                 `json:"name"`
-	Description          *string                `json:"description,omitempty"`
-	Status               TeamStatus             `json:"status,omitempty"`
-	Runtime              *string                `json:"runtime,omitempty"`
-	Configuration        map[string]interface{} `json:"configuration,omitempty"`
-	SkillIDs             []string               `json:"skill_ids,omitempty"`
-	ExecutionEnvironment map[string]interface{} `json:"execution_environment,omitempty"`
-	CreatedAt            *time.Time             `json:"created_at,omitempty"`
-	UpdatedAt            *time.Time             `json:"updated_at,omitempty"`
-}
-
-// TeamCreateRequest represents the request to create a team
-type TeamCreateRequest struct {
-	Name                 string                 `json:"name"`
-	Description          *string                `json:"description,omitempty"`
-	Runtime              *string                `json:"runtime,omitempty"`
-	Configuration        map[string]interface{} `json:"configuration,omitempty"`
-	SkillIDs             []string               `json:"skill_ids,omitempty"`
-	ExecutionEnvironment map[string]interface{} `json:"execution_environment,omitempty"`
-}
+const (
+	TeamStatusActive   = controlplane.TeamStatusActive
+	TeamStatusInactive = controlplane.TeamStatusInactive
+	TeamStatusArchived = controlplane.TeamStatusArchived
 
-// TeamUpdateRequest represents the request to update a team
-type TeamUpdateRequest struct {
-	Name                 *string                `json:"name,omitempty"`
-	Description          *string                `json:"description,omitempty"`
-	Status               *TeamStatus            `json:"status,omitempty"`
-	Runtime              *string                `json:"runtime,omitempty"`
-	Configuration        map[string]interface{} `json:"configuration,omitempty"`
-	SkillIDs             []string               `json:"skill_ids,omitempty"`
-	ExecutionEnvironment map[string]interface{} `json:"execution_environment,omitempty"`
-}
+	TeamRoleMember = controlplane.TeamRoleMember
+	TeamRoleLeader = controlplane.TeamRoleLeader
+)
@@ -0,0 +1,79 @@
+package generate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+)
+
+// apiKeyEnvVar is the environment variable the API key is read from, as for the provider
+const apiKeyEnvVar = "KUBIYA_CONTROL_PLANE_API_KEY"
+
+// Run executes the generate command with the given command-line arguments and returns the exit code.
+// Canceling ctx aborts the API requests.
+func Run(ctx context.Context, args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	out := flags.String("out", "generated.tf", "file to write the generated configuration to (\"-\" for stdout)")
+	types := flags.String("types", "", "comma-separated resource types to generate (default: all of "+strings.Join(AllTypes, ", ")+")")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-kubiya-control-plane generate [-out FILE] [-types TYPES]\n\n")
+		fmt.Fprintf(stderr, "Writes import and resource blocks for every object in the organization of %s.\n\n", apiKeyEnvVar)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var selected []string
+	if *types != "" {
+		for _, t := range strings.Split(*types, ",") {
+			selected = append(selected, strings.TrimSpace(t))
+		}
+	}
+
+	apiKey := os.Getenv(apiKeyEnvVar)
+	if apiKey == "" {
+		fmt.Fprintf(stderr, "Error: %s is not set\n", apiKeyEnvVar)
+		return 1
+	}
+
+	client, err := clients.New(apiKey)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	generator, err := New(client, selected)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	// Render to memory first so a failed listing never leaves a truncated file behind
+	var buf strings.Builder
+	if err := generator.Generate(ctx, &buf); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	if *out == "-" {
+		fmt.Print(buf.String())
+	} else if err := os.WriteFile(*out, []byte(buf.String()), 0o644); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(stderr, "Generated %s\n", strings.Join(SortedCounts(generator.Counts()), ", "))
+	if *out != "-" {
+		fmt.Fprintf(stderr, "Configuration written to %s; review it, then run terraform plan to import\n", *out)
+	}
+
+	return 0
+}
@@ -0,0 +1,574 @@
+// Package generate renders Terraform configuration for objects that already exist in the Control Plane,
+// together with import blocks, so that an organization created by hand can be brought under Terraform.
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
+)
+
+// Resource types the generator knows how to render, in the order they are emitted
+const (
+	TypeEnvironments = "environments"
+	TypeSkills       = "skills"
+	TypePolicies     = "policies"
+	TypeTeams        = "teams"
+	TypeProjects     = "projects"
+	TypeAgents       = "agents"
+	TypeWorkerQueues = "worker_queues"
+	TypeJobs         = "jobs"
+)
+
+// AllTypes lists every resource type supported by the generator
+var AllTypes = []string{
+	TypeEnvironments,
+	TypeSkills,
+	TypePolicies,
+	TypeTeams,
+	TypeProjects,
+	TypeAgents,
+	TypeWorkerQueues,
+	TypeJobs,
+}
+
+var invalidLabelChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// Generator renders import and resource blocks for existing Control Plane objects
+type Generator struct {
+	client *clients.Client
+	types  map[string]bool
+
+	// addresses maps object IDs to the resource address generated for them
+	addresses map[string]hcl.Traversal
+	// labels tracks the labels already used per resource type
+	labels map[string]map[string]bool
+
+	environments []*entities.Environment
+	skills       []*entities.Skill
+	policies     []*entities.Policy
+	teams        []*entities.Team
+	projects     []*entities.Project
+	agents       []*entities.Agent
+	queues       []*entities.WorkerQueue
+	jobs         []*entities.Job
+
+	counts map[string]int
+}
+
+// New creates a generator for the given resource types; an empty list selects all types
+func New(client *clients.Client, types []string) (*Generator, error) {
+	selected := make(map[string]bool)
+	for _, t := range types {
+		if !isKnownType(t) {
+			return nil, fmt.Errorf("unknown resource type %q (supported: %s)", t, strings.Join(AllTypes, ", "))
+		}
+		selected[t] = true
+	}
+
+	if len(selected) == 0 {
+		for _, t := range AllTypes {
+			selected[t] = true
+		}
+	}
+
+	return &Generator{
+		client:    client,
+		types:     selected,
+		addresses: make(map[string]hcl.Traversal),
+		labels:    make(map[string]map[string]bool),
+		counts:    make(map[string]int),
+	}, nil
+}
+
+// Counts returns how many objects of each resource type were generated
+func (g *Generator) Counts() map[string]int {
+	return g.counts
+}
+
+// Generate fetches every selected object and writes the configuration to w
+func (g *Generator) Generate(ctx context.Context, w io.Writer) error {
+	if err := g.fetch(ctx); err != nil {
+		return err
+	}
+
+	// Assign every address up front so references resolve regardless of emission order
+	g.assignAddresses()
+
+	file := hclwrite.NewEmptyFile()
+	body := file.Body()
+
+	for _, env := range g.environments {
+		if err := g.writeEnvironment(body, env); err != nil {
+			return fmt.Errorf("failed to generate environment %q (%s): %w", env.Name, env.ID, err)
+		}
+	}
+	for _, skill := range g.skills {
+		if err := g.writeSkill(body, skill); err != nil {
+			return fmt.Errorf("failed to generate skill %q (%s): %w", skill.Name, skill.ID, err)
+		}
+	}
+	for _, policy := range g.policies {
+		g.writePolicy(body, policy)
+	}
+	for _, team := range g.teams {
+		if err := g.writeTeam(body, team); err != nil {
+			return fmt.Errorf("failed to generate team %q (%s): %w", team.Name, team.ID, err)
+		}
+	}
+	for _, project := range g.projects {
+		if err := g.writeProject(body, project); err != nil {
+			return fmt.Errorf("failed to generate project %q (%s): %w", project.Key, project.ID, err)
+		}
+	}
+	for _, agent := range g.agents {
+		if err := g.writeAgent(body, agent); err != nil {
+			return fmt.Errorf("failed to generate agent %q (%s): %w", agent.Name, agent.ID, err)
+		}
+	}
+	for _, queue := range g.queues {
+		if err := g.writeWorkerQueue(body, queue); err != nil {
+			return fmt.Errorf("failed to generate worker queue %q (%s): %w", queue.Name, queue.ID, err)
+		}
+	}
+	for _, job := range g.jobs {
+		if err := g.writeJob(body, job); err != nil {
+			return fmt.Errorf("failed to generate job %q (%s): %w", job.Name, job.ID, err)
+		}
+	}
+
+	_, err := w.Write(hclwrite.Format(file.Bytes()))
+	return err
+}
+
+// fetch lists every selected object from the API
+func (g *Generator) fetch(ctx context.Context) error {
+	var err error
+
+	// Environments are also needed to list worker queues
+	if g.types[TypeEnvironments] || g.types[TypeWorkerQueues] {
+		if g.environments, err = g.client.ListEnvironments(ctx); err != nil {
+			return fmt.Errorf("failed to list environments: %w", err)
+		}
+	}
+
+	if g.types[TypeWorkerQueues] {
+		for _, env := range g.environments {
+			queues, err := g.client.ListWorkerQueues(ctx, env.ID)
+			if err != nil {
+				return fmt.Errorf("failed to list worker queues of environment %s: %w", env.Name, err)
+			}
+			g.queues = append(g.queues, queues...)
+		}
+	}
+
+	if !g.types[TypeEnvironments] {
+		g.environments = nil
+	}
+
+	if g.types[TypeSkills] {
+		if g.skills, err = g.client.ListSkills(ctx); err != nil {
+			return fmt.Errorf("failed to list skills: %w", err)
+		}
+	}
+
+	if g.types[TypePolicies] {
+		if g.policies, err = g.client.ListPolicies(ctx); err != nil {
+			return fmt.Errorf("failed to list policies: %w", err)
+		}
+	}
+
+	if g.types[TypeTeams] {
+		if g.teams, err = g.client.ListTeams(ctx); err != nil {
+			return fmt.Errorf("failed to list teams: %w", err)
+		}
+	}
+
+	if g.types[TypeProjects] {
+		if g.projects, err = g.client.ListProjects(ctx); err != nil {
+			return fmt.Errorf("failed to list projects: %w", err)
+		}
+	}
+
+	if g.types[TypeAgents] {
+		if g.agents, err = g.client.ListAgents(ctx); err != nil {
+			return fmt.Errorf("failed to list agents: %w", err)
+		}
+	}
+
+	if g.types[TypeJobs] {
+		if g.jobs, err = g.client.ListJobs(ctx); err != nil {
+			return fmt.Errorf("failed to list jobs: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// assignAddresses gives every fetched object a unique resource address
+func (g *Generator) assignAddresses() {
+	for _, env := range g.environments {
+		g.assign("controlplane_environment", env.ID, env.Name)
+	}
+	for _, skill := range g.skills {
+		g.assign("controlplane_skill", skill.ID, skill.Name)
+	}
+	for _, policy := range g.policies {
+		g.assign("controlplane_policy", policy.ID, policy.Name)
+	}
+	for _, team := range g.teams {
+		g.assign("controlplane_team", team.ID, team.Name)
+	}
+	for _, project := range g.projects {
+		g.assign("controlplane_project", project.ID, project.Key)
+	}
+	for _, agent := range g.agents {
+		g.assign("controlplane_agent", agent.ID, agent.Name)
+	}
+	for _, queue := range g.queues {
+		g.assign("controlplane_worker_queue", queue.ID, queue.Name)
+	}
+	for _, job := range g.jobs {
+		g.assign("controlplane_job", job.ID, job.Name)
+	}
+}
+
+// assign derives a valid, unique resource label from name and records the address for id
+func (g *Generator) assign(resourceType, id, name string) {
+	label := invalidLabelChars.ReplaceAllString(strings.ToLower(name), "_")
+	label = strings.Trim(label, "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "r_" + label
+	}
+
+	used := g.labels[resourceType]
+	if used == nil {
+		used = make(map[string]bool)
+		g.labels[resourceType] = used
+	}
+
+	unique := label
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", label, i)
+	}
+	used[unique] = true
+
+	g.addresses[id] = hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: unique},
+	}
+}
+
+// startResource writes the import block and opens the resource block for an object
+func (g *Generator) startResource(body *hclwrite.Body, kind, id string) *hclwrite.Body {
+	address := g.addresses[id]
+	resourceType := address.RootName()
+	label := address[1].(hcl.TraverseAttr).Name
+	g.counts[kind]++
+
+	importBlock := body.AppendNewBlock("import", nil)
+	importBlock.Body().SetAttributeTraversal("to", address)
+	importBlock.Body().SetAttributeValue("id", cty.StringVal(id))
+	body.AppendNewline()
+
+	block := body.AppendNewBlock("resource", []string{resourceType, label})
+	body.AppendNewline()
+
+	return block.Body()
+}
+
+func (g *Generator) writeEnvironment(body *hclwrite.Body, env *entities.Environment) error {
+	b := g.startResource(body, TypeEnvironments, env.ID)
+	setString(b, "name", env.Name)
+	setOptionalString(b, "display_name", env.DisplayName)
+	setOptionalString(b, "description", env.Description)
+	setStringList(b, "tags", env.Tags)
+	if err := setJSON(b, "settings", env.Settings); err != nil {
+		return err
+	}
+	return setJSON(b, "execution_environment", env.ExecutionEnvironment)
+}
+
+func (g *Generator) writeSkill(body *hclwrite.Body, skill *entities.Skill) error {
+	b := g.startResource(body, TypeSkills, skill.ID)
+	setString(b, "name", skill.Name)
+	setString(b, "type", string(skill.Type))
+	setOptionalString(b, "description", skill.Description)
+	setString(b, "icon", skill.Icon)
+	b.SetAttributeValue("enabled", cty.BoolVal(skill.Enabled))
+	return setJSON(b, "configuration", skill.Configuration)
+}
+
+func (g *Generator) writePolicy(body *hclwrite.Body, policy *entities.Policy) {
+	b := g.startResource(body, TypePolicies, policy.ID)
+	setString(b, "name", policy.Name)
+	setOptionalString(b, "description", policy.Description)
+	setMultilineString(b, "policy_content", policy.PolicyContent)
+	setString(b, "policy_type", string(policy.PolicyType))
+	b.SetAttributeValue("enabled", cty.BoolVal(policy.Enabled))
+	setStringList(b, "tags", policy.Tags)
+}
+
+func (g *Generator) writeTeam(body *hclwrite.Body, team *entities.Team) error {
+	b := g.startResource(body, TypeTeams, team.ID)
+	setString(b, "name", team.Name)
+	setOptionalString(b, "description", team.Description)
+	setOptionalString(b, "runtime", team.Runtime)
+	if err := setJSON(b, "configuration", team.Configuration); err != nil {
+		return err
+	}
+	g.setReferenceList(b, "skill_ids", team.SkillIDs)
+	return setJSON(b, "execution_environment", team.ExecutionEnvironment)
+}
+
+func (g *Generator) writeProject(body *hclwrite.Body, project *entities.Project) error {
+	b := g.startResource(body, TypeProjects, project.ID)
+	setString(b, "name", project.Name)
+	setString(b, "key", project.Key)
+	setOptionalString(b, "description", project.Description)
+	setOptionalString(b, "goals", project.Goals)
+	if err := setJSON(b, "settings", project.Settings); err != nil {
+		return err
+	}
+	setString(b, "visibility", project.Visibility)
+	b.SetAttributeValue("restrict_to_environment", cty.BoolVal(project.RestrictToEnvironment))
+	g.setReferenceList(b, "policy_ids", project.PolicyIDs)
+	setOptionalString(b, "default_model", project.DefaultModel)
+	return nil
+}
+
+func (g *Generator) writeAgent(body *hclwrite.Body, agent *entities.Agent) error {
+	b := g.startResource(body, TypeAgents, agent.ID)
+	setString(b, "name", agent.Name)
+	setOptionalString(b, "description", agent.Description)
+	setStringList(b, "capabilities", agent.Capabilities)
+	if err := setJSON(b, "configuration", agent.Configuration); err != nil {
+		return err
+	}
+	setOptionalString(b, "model_id", agent.ModelID)
+	if err := setJSON(b, "llm_config", agent.LLMConfig); err != nil {
+		return err
+	}
+	setString(b, "runtime", string(agent.Runtime))
+	if agent.TeamID != nil {
+		g.setReference(b, "team_id", *agent.TeamID)
+	}
+	return nil
+}
+
+func (g *Generator) writeWorkerQueue(body *hclwrite.Body, queue *entities.WorkerQueue) error {
+	b := g.startResource(body, TypeWorkerQueues, queue.ID)
+	g.setReference(b, "environment_id", queue.EnvironmentID)
+	setString(b, "name", queue.Name)
+	setOptionalString(b, "display_name", queue.DisplayName)
+	setOptionalString(b, "description", queue.Description)
+	setString(b, "status", string(queue.Status))
+	if queue.MaxWorkers != nil {
+		b.SetAttributeValue("max_workers", cty.NumberIntVal(int64(*queue.MaxWorkers)))
+	}
+	b.SetAttributeValue("heartbeat_interval", cty.NumberIntVal(int64(queue.HeartbeatInterval)))
+	setStringList(b, "tags", queue.Tags)
+	return setJSON(b, "settings", queue.Settings)
+}
+
+func (g *Generator) writeJob(body *hclwrite.Body, job *entities.Job) error {
+	b := g.startResource(body, TypeJobs, job.ID)
+	setString(b, "name", job.Name)
+	setOptionalString(b, "description", job.Description)
+	b.SetAttributeValue("enabled", cty.BoolVal(job.Enabled))
+	setString(b, "trigger_type", job.TriggerType)
+	setOptionalString(b, "cron_schedule", job.CronSchedule)
+	setOptionalString(b, "cron_timezone", job.CronTimezone)
+	setString(b, "planning_mode", job.PlanningMode)
+	setOptionalString(b, "entity_type", job.EntityType)
+	if job.EntityID != nil {
+		g.setReference(b, "entity_id", *job.EntityID)
+	}
+	setMultilineString(b, "prompt_template", job.PromptTemplate)
+	if job.SystemPrompt != nil {
+		setMultilineString(b, "system_prompt", *job.SystemPrompt)
+	}
+	setString(b, "executor_type", job.ExecutorType)
+	setOptionalString(b, "worker_queue_name", job.WorkerQueueName)
+	setOptionalString(b, "environment_name", job.EnvironmentName)
+	if err := setJSON(b, "config", job.Config); err != nil {
+		return err
+	}
+	if job.ExecutionEnv != nil {
+		if len(job.ExecutionEnv.EnvVars) > 0 {
+			vars := make(map[string]cty.Value, len(job.ExecutionEnv.EnvVars))
+			for k, v := range job.ExecutionEnv.EnvVars {
+				vars[k] = cty.StringVal(v)
+			}
+			b.SetAttributeValue("execution_env_vars", cty.MapVal(vars))
+		}
+		setStringList(b, "execution_secrets", job.ExecutionEnv.Secrets)
+		setStringList(b, "execution_integrations", job.ExecutionEnv.IntegrationIDs)
+	}
+	return nil
+}
+
+// setReference writes a reference to the generated resource for id, or the literal id when
+// the object is not part of the generated configuration
+func (g *Generator) setReference(b *hclwrite.Body, name, id string) {
+	if id == "" {
+		return
+	}
+
+	b.SetAttributeRaw(name, g.referenceTokens(id))
+}
+
+// setReferenceList writes a list of references, falling back to literal IDs for unknown objects
+func (g *Generator) setReferenceList(b *hclwrite.Body, name string, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+
+	elems := make([]hclwrite.Tokens, 0, len(ids))
+	for _, id := range ids {
+		elems = append(elems, g.referenceTokens(id))
+	}
+
+	b.SetAttributeRaw(name, hclwrite.TokensForTuple(elems))
+}
+
+func (g *Generator) referenceTokens(id string) hclwrite.Tokens {
+	address, ok := g.addresses[id]
+	if !ok {
+		return hclwrite.TokensForValue(cty.StringVal(id))
+	}
+
+	return hclwrite.TokensForTraversal(append(address, hcl.TraverseAttr{Name: "id"}))
+}
+
+func setString(b *hclwrite.Body, name, value string) {
+	if value != "" {
+		b.SetAttributeValue(name, cty.StringVal(value))
+	}
+}
+
+func setOptionalString(b *hclwrite.Body, name string, value *string) {
+	if value != nil {
+		setString(b, name, *value)
+	}
+}
+
+func setStringList(b *hclwrite.Body, name string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+
+	elems := make([]cty.Value, len(values))
+	for i, v := range values {
+		elems[i] = cty.StringVal(v)
+	}
+	b.SetAttributeValue(name, cty.ListVal(elems))
+}
+
+// setMultilineString writes value as a heredoc when it spans several lines and ends with a newline,
+// which keeps policies and prompts readable while preserving their exact content
+func setMultilineString(b *hclwrite.Body, name, value string) {
+	if !strings.Contains(strings.TrimSuffix(value, "\n"), "\n") || !strings.HasSuffix(value, "\n") {
+		setString(b, name, value)
+		return
+	}
+
+	delimiter := heredocDelimiter(value)
+	escaped := strings.ReplaceAll(value, "${", "$${")
+	escaped = strings.ReplaceAll(escaped, "%{", "%%{")
+
+	b.SetAttributeRaw(name, hclwrite.Tokens{
+		{Type: hclsyntax.TokenOHeredoc, Bytes: []byte("<<" + delimiter + "\n")},
+		{Type: hclsyntax.TokenStringLit, Bytes: []byte(escaped)},
+		{Type: hclsyntax.TokenCHeredoc, Bytes: []byte(delimiter)},
+	})
+}
+
+// heredocDelimiter returns EOT, or EOT followed by a number when a line of value would close a heredoc
+// opened with EOT. HCL closes a heredoc at the first line that is only the delimiter, ignoring
+// surrounding whitespace.
+func heredocDelimiter(value string) string {
+	lines := make(map[string]bool)
+	for _, line := range strings.Split(value, "\n") {
+		lines[strings.TrimSpace(line)] = true
+	}
+
+	delimiter := "EOT"
+	for i := 2; lines[delimiter]; i++ {
+		delimiter = fmt.Sprintf("EOT%d", i)
+	}
+
+	return delimiter
+}
+
+// setJSON writes a JSON object attribute as jsonencode({...}) with HCL syntax for its content.
+// The error names the attribute, since leaving it out would make the next plan change the object.
+func setJSON(b *hclwrite.Body, name string, value map[string]interface{}) error {
+	if len(value) == 0 {
+		return nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+
+	ty, err := ctyjson.ImpliedType(encoded)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s: %w", name, err)
+	}
+
+	val, err := ctyjson.Unmarshal(encoded, ty)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s: %w", name, err)
+	}
+
+	b.SetAttributeRaw(name, hclwrite.TokensForFunctionCall("jsonencode", hclwrite.TokensForValue(val)))
+	return nil
+}
+
+func isKnownType(t string) bool {
+	for _, known := range AllTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// SortedCounts returns the generated counts as "type: n" strings in emission order
+func SortedCounts(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return typeIndex(keys[i]) < typeIndex(keys[j]) })
+
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		out[i] = fmt.Sprintf("%s: %d", k, counts[k])
+	}
+	return out
+}
+
+func typeIndex(t string) int {
+	for i, known := range AllTypes {
+		if t == known {
+			return i
+		}
+	}
+	return len(AllTypes)
+}
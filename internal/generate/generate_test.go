@@ -0,0 +1,210 @@
+package generate
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
+	"terraform-provider-kubiya-control-plane/pkg/fakeserver"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata with the current output")
+
+// policyContent has lines that would close a heredoc delimited by EOT, and template sequences that
+// must stay literal
+const policyContent = `package kubiya.approval
+
+EOT
+  EOT
+default allow := false
+
+allow if {
+	input.message == "${user} approved"
+	not contains(input.message, "%{if}")
+}
+`
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// organization is the fake organization the golden files are generated from. ids lists the IDs
+// of the created objects in creation order, so that they can be replaced with stable ones.
+type organization struct {
+	client *clients.Client
+	ids    []string
+}
+
+func newOrganization(t *testing.T) *organization {
+	t.Helper()
+
+	ctx := context.Background()
+	httpServer := httptest.NewServer(fakeserver.New())
+	t.Cleanup(httpServer.Close)
+
+	t.Setenv("KUBIYA_CONTROL_PLANE_BASE_URL", httpServer.URL)
+	client, err := clients.New("test-api-key")
+	require.NoError(t, err)
+
+	org := &organization{client: client}
+
+	env, err := client.CreateEnvironment(ctx, &entities.EnvironmentCreateRequest{
+		Name:     "production",
+		Settings: map[string]interface{}{"region": "eu-west-1", "replicas": 3, "labels": map[string]interface{}{"tier": "gold"}},
+	})
+	require.NoError(t, err)
+	org.ids = append(org.ids, env.ID)
+
+	queue, err := client.CreateWorkerQueue(ctx, env.ID, &entities.WorkerQueueCreateRequest{
+		Name:              "gpu",
+		HeartbeatInterval: 30,
+		Settings:          map[string]interface{}{"autoscale": true},
+	})
+	require.NoError(t, err)
+	org.ids = append(org.ids, queue.ID)
+
+	policy, err := client.CreatePolicy(ctx, &entities.PolicyCreateRequest{
+		Name:          "Require Approval",
+		PolicyContent: policyContent,
+		PolicyType:    entities.PolicyTypeRego,
+		Enabled:       true,
+	})
+	require.NoError(t, err)
+	org.ids = append(org.ids, policy.ID)
+
+	team, err := client.CreateTeam(ctx, &entities.TeamCreateRequest{Name: "platform", Runtime: ptr("default")})
+	require.NoError(t, err)
+	org.ids = append(org.ids, team.ID)
+
+	project, err := client.CreateProject(ctx, &entities.ProjectCreateRequest{
+		Name:      "Operations",
+		Key:       "OPS",
+		PolicyIDs: []string{policy.ID},
+	})
+	require.NoError(t, err)
+	org.ids = append(org.ids, project.ID)
+
+	agent, err := client.CreateAgent(ctx, &entities.AgentCreateRequest{
+		Name:          "deployer",
+		TeamID:        &team.ID,
+		Configuration: map[string]interface{}{"tools": []interface{}{"kubectl", "helm"}, "max_steps": 20},
+	})
+	require.NoError(t, err)
+	org.ids = append(org.ids, agent.ID)
+
+	return org
+}
+
+// generate runs the generator for the given types and replaces the IDs of the organization with
+// stable ones
+func (org *organization) generate(t *testing.T, types []string) string {
+	t.Helper()
+
+	ctx := context.Background()
+	generator, err := New(org.client, types)
+	require.NoError(t, err)
+
+	var out strings.Builder
+	require.NoError(t, generator.Generate(ctx, &out))
+
+	output := out.String()
+	for i, id := range org.ids {
+		output = strings.ReplaceAll(output, id, fmt.Sprintf("00000000-0000-0000-0000-%012d", i+1))
+	}
+
+	return output
+}
+
+// assertGolden compares output with the golden file testdata/<name>, rewriting it with -update
+func assertGolden(t *testing.T, name, output string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(output), 0o644))
+	}
+
+	golden, err := os.ReadFile(path)
+	require.NoError(t, err, "run go test with -update to create the golden file")
+	assert.Equal(t, string(golden), output)
+}
+
+// parse parses generated configuration, failing the test on invalid HCL
+func parse(t *testing.T, output string) *hclsyntax.Body {
+	t.Helper()
+
+	file, diags := hclsyntax.ParseConfig([]byte(output), "generated.tf", hcl.InitialPos)
+	require.False(t, diags.HasErrors(), "generated configuration is not valid HCL: %s", diags)
+
+	return file.Body.(*hclsyntax.Body)
+}
+
+func TestGenerate(t *testing.T) {
+	org := newOrganization(t)
+
+	output := org.generate(t, nil)
+	assertGolden(t, "all.tf.golden", output)
+
+	body := parse(t, output)
+
+	// The policy content survives the heredoc unchanged
+	for _, block := range body.Blocks {
+		if block.Type != "resource" || block.Labels[0] != "controlplane_policy" {
+			continue
+		}
+		value, diags := block.Body.Attributes["policy_content"].Expr.Value(nil)
+		require.False(t, diags.HasErrors(), diags.Error())
+		assert.Equal(t, policyContent, value.AsString())
+		return
+	}
+	t.Fatal("no controlplane_policy resource generated")
+}
+
+func TestGenerateReferencesOutsideSelection(t *testing.T) {
+	org := newOrganization(t)
+
+	// Teams, environments and policies are not generated, so agents, worker queues and projects
+	// keep the literal IDs of the objects they refer to
+	output := org.generate(t, []string{TypeAgents, TypeWorkerQueues, TypeProjects})
+	assertGolden(t, "literal_ids.tf.golden", output)
+
+	parse(t, output)
+}
+
+func TestHeredocDelimiter(t *testing.T) {
+	tests := map[string]string{
+		"allow := true\n":             "EOT",
+		"EOT\nallow := true\n":        "EOT2",
+		"allow := true\n\t EOT \n":    "EOT2",
+		"EOT\nEOT2\n":                 "EOT3",
+		"EOTX\nallow := true\nxEOT\n": "EOT",
+	}
+
+	for value, want := range tests {
+		assert.Equal(t, want, heredocDelimiter(value), "value %q", value)
+	}
+}
+
+func TestSetJSONError(t *testing.T) {
+	body := hclwrite.NewEmptyFile().Body()
+
+	err := setJSON(body, "settings", map[string]interface{}{"ratio": math.Inf(1)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "settings")
+	assert.Nil(t, body.GetAttribute("settings"))
+}
@@ -4,14 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
 )
 
-var _ datasource.DataSource = (*agentDataSource)(nil)
+var (
+	_ datasource.DataSource                     = (*agentDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*agentDataSource)(nil)
+)
 
 func NewAgentDataSource() datasource.DataSource {
 	return &agentDataSource{}
@@ -45,11 +52,13 @@ func (d *agentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 		Description: "Fetches an existing Agent from the Control Plane.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Agent ID to lookup",
-				Required:    true,
+				Description: "Agent ID to lookup. Exactly one of id or name must be set",
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "Agent name",
+				Description: "Agent name to lookup. Exactly one of id or name must be set",
+				Optional:    true,
 				Computed:    true,
 			},
 			"description": schema.StringAttribute{
@@ -97,6 +106,15 @@ func (d *agentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 	}
 }
 
+func (d *agentDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
 func (d *agentDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -122,22 +140,45 @@ func (d *agentDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	agent, err := d.client.GetAgent(config.ID.ValueString())
+	id := config.ID.ValueString()
+	if config.ID.IsNull() {
+		var err error
+		id, err = lookupAgentID(ctx, d.client, config.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading agent", err.Error())
+			return
+		}
+	}
+
+	agent, err := d.client.GetAgent(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading agent", err.Error())
 		return
 	}
 
-	// Map response to state
-	config.Name = types.StringValue(agent.Name)
+	resp.Diagnostics.Append(populateAgentDataSourceModel(ctx, &config, agent)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// populateAgentDataSourceModel copies the fields of an API agent into a data source model
+func populateAgentDataSourceModel(ctx context.Context, model *agentDataSourceModel, agent *entities.Agent) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(agent.ID)
+	model.Name = types.StringValue(agent.Name)
 
 	if agent.Description != nil {
-		config.Description = types.StringValue(*agent.Description)
+		model.Description = types.StringValue(*agent.Description)
 	} else {
-		config.Description = types.StringNull()
+		model.Description = types.StringNull()
 	}
 
-	config.Status = types.StringValue(string(agent.Status))
+	model.Status = types.StringValue(string(agent.Status))
 
 	// Convert capabilities to list
 	if len(agent.Capabilities) > 0 {
@@ -145,63 +186,62 @@ func (d *agentDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		for i, cap := range agent.Capabilities {
 			capList[i] = types.StringValue(cap)
 		}
-		listVal, diags := types.ListValueFrom(ctx, types.StringType, capList)
-		resp.Diagnostics.Append(diags...)
-		config.Capabilities = listVal
+		listVal, listDiags := types.ListValueFrom(ctx, types.StringType, capList)
+		diags.Append(listDiags...)
+		model.Capabilities = listVal
 	} else {
-		config.Capabilities = types.ListNull(types.StringType)
+		model.Capabilities = types.ListNull(types.StringType)
 	}
 
 	// Convert configuration to JSON string
 	if len(agent.Configuration) > 0 {
 		configJSON, err := toJSONString(agent.Configuration)
 		if err != nil {
-			resp.Diagnostics.AddError("Error converting configuration", err.Error())
-			return
+			diags.AddError("Error converting configuration", err.Error())
+			return diags
 		}
-		config.Configuration = types.StringValue(configJSON)
+		model.Configuration = types.StringValue(configJSON)
 	} else {
-		config.Configuration = types.StringNull()
+		model.Configuration = types.StringNull()
 	}
 
 	if agent.ModelID != nil {
-		config.ModelID = types.StringValue(*agent.ModelID)
+		model.ModelID = types.StringValue(*agent.ModelID)
 	} else {
-		config.ModelID = types.StringNull()
+		model.ModelID = types.StringNull()
 	}
 
 	// Convert LLM config to JSON string
 	if len(agent.LLMConfig) > 0 {
 		llmConfigJSON, err := toJSONString(agent.LLMConfig)
 		if err != nil {
-			resp.Diagnostics.AddError("Error converting llm_config", err.Error())
-			return
+			diags.AddError("Error converting llm_config", err.Error())
+			return diags
 		}
-		config.LLMConfig = types.StringValue(llmConfigJSON)
+		model.LLMConfig = types.StringValue(llmConfigJSON)
 	} else {
-		config.LLMConfig = types.StringNull()
+		model.LLMConfig = types.StringNull()
 	}
 
-	config.Runtime = types.StringValue(string(agent.Runtime))
+	model.Runtime = types.StringValue(string(agent.Runtime))
 
 	if agent.TeamID != nil {
-		config.TeamID = types.StringValue(*agent.TeamID)
+		model.TeamID = types.StringValue(*agent.TeamID)
 	} else {
-		config.TeamID = types.StringNull()
+		model.TeamID = types.StringNull()
 	}
 
 	if agent.CreatedAt != nil {
-		config.CreatedAt = types.StringValue(agent.CreatedAt.String())
+		model.CreatedAt = types.StringValue(agent.CreatedAt.String())
 	} else {
-		config.CreatedAt = types.StringNull()
+		model.CreatedAt = types.StringNull()
 	}
 
 	if agent.UpdatedAt != nil {
-		config.UpdatedAt = types.StringValue(agent.UpdatedAt.String())
+		model.UpdatedAt = types.StringValue(agent.UpdatedAt.String())
 	} else {
-		config.UpdatedAt = types.StringNull()
+		model.UpdatedAt = types.StringNull()
 	}
 
-	diags = resp.State.Set(ctx, &config)
-	resp.Diagnostics.Append(diags...)
+	return diags
 }
@@ -93,8 +93,12 @@ func (r *agentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:    true,
 			},
 			"team_id": schema.StringAttribute{
-				Description: "Team ID to assign this agent to",
+				Description: "Team ID to assign this agent to. When unset, the team is left as is, so it can be managed with controlplane_team_member or controlplane_team_members instead. Removing team_id from the configuration does not take the agent out of its team; remove it from controlplane_team_members or destroy its controlplane_team_member for that",
 				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"created_at": schema.StringAttribute{
 				Description: "Timestamp when the agent was created",
@@ -190,13 +194,13 @@ func (r *agentResource) Create(ctx context.Context, req resource.CreateRequest,
 		createReq.Runtime = &runtime
 	}
 
-	if !plan.TeamID.IsNull() {
+	if !plan.TeamID.IsNull() && !plan.TeamID.IsUnknown() {
 		teamID := plan.TeamID.ValueString()
 		createReq.TeamID = &teamID
 	}
 
 	// Create agent
-	agent, err := r.client.CreateAgent(createReq)
+	agent, err := r.client.CreateAgent(ctx, createReq)
 	if err != nil {
 		logger.Error("Failed to create agent", "error", err)
 		kubiyasentry.RecordError(ctx, err)
@@ -232,6 +236,8 @@ func (r *agentResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	if agent.TeamID != nil {
 		plan.TeamID = types.StringValue(*agent.TeamID)
+	} else {
+		plan.TeamID = types.StringNull()
 	}
 
 	// Keep the input values for fields not returned by API
@@ -267,7 +273,7 @@ func (r *agentResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	logger := kubiyasentry.LoggerFromContext(ctx)
 	logger.Debug("Reading agent resource", "agent_id", state.ID.ValueString())
 
-	agent, err := r.client.GetAgent(state.ID.ValueString())
+	agent, err := r.client.GetAgent(ctx, state.ID.ValueString())
 	if err != nil {
 		logger.Error("Failed to read agent", "error", err, "agent_id", state.ID.ValueString())
 		kubiyasentry.RecordError(ctx, err)
@@ -300,6 +306,8 @@ func (r *agentResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	if agent.TeamID != nil {
 		state.TeamID = types.StringValue(*agent.TeamID)
+	} else {
+		state.TeamID = types.StringNull()
 	}
 
 	// Keep existing values for fields not returned by API
@@ -374,8 +382,13 @@ func (r *agentResource) Update(ctx context.Context, req resource.UpdateRequest,
 		updateReq.TeamID = &teamID
 	}
 
+	resp.Diagnostics.Append(checkNotModifiedSinceRefresh(ctx, r.client, req.State, "agent", (*clients.Client).GetAgent)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Update agent
-	agent, err := r.client.UpdateAgent(state.ID.ValueString(), updateReq)
+	agent, err := r.client.UpdateAgent(ctx, state.ID.ValueString(), updateReq)
 	if err != nil {
 		logger.Error("Failed to update agent", "error", err, "agent_id", state.ID.ValueString())
 		kubiyasentry.RecordError(ctx, err)
@@ -411,8 +424,16 @@ func (r *agentResource) Update(ctx context.Context, req resource.UpdateRequest,
 		plan.Runtime = types.StringValue(string(agent.Runtime))
 	}
 
-	if agent.TeamID != nil {
-		plan.TeamID = types.StringValue(*agent.TeamID)
+	// Without team_id in the configuration the planned team is the one in state. Keep it even when
+	// controlplane_team_members moved the agent earlier in this apply; the next refresh records the move.
+	var configTeamID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("team_id"), &configTeamID)...)
+	if !configTeamID.IsNull() || plan.TeamID.IsUnknown() {
+		if agent.TeamID != nil {
+			plan.TeamID = types.StringValue(*agent.TeamID)
+		} else {
+			plan.TeamID = types.StringNull()
+		}
 	}
 
 	if agent.CreatedAt != nil {
@@ -442,7 +463,7 @@ func (r *agentResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	logger := kubiyasentry.LoggerFromContext(ctx)
 	logger.Info("Deleting agent resource", "agent_id", state.ID.ValueString())
 
-	err := r.client.DeleteAgent(state.ID.ValueString())
+	err := r.client.DeleteAgent(ctx, state.ID.ValueString())
 	if err != nil {
 		logger.Error("Failed to delete agent", "error", err, "agent_id", state.ID.ValueString())
 		kubiyasentry.RecordError(ctx, err)
@@ -455,6 +476,9 @@ func (r *agentResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	kubiyasentry.SetSpanStatus(span, sentry.SpanStatusOK)
 }
 
+// ImportState accepts either the agent ID or the agent name
 func (r *agentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByLookup(ctx, req, resp, "agent", func(name string) (string, error) {
+		return lookupAgentID(ctx, r.client, name)
+	})
 }
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+)
+
+var _ datasource.DataSource = (*agentsDataSource)(nil)
+
+func NewAgentsDataSource() datasource.DataSource {
+	return &agentsDataSource{}
+}
+
+type agentsDataSource struct {
+	client *clients.Client
+}
+
+type agentsDataSourceModel struct {
+	NameRegex types.String           `tfsdk:"name_regex"`
+	Status    types.String           `tfsdk:"status"`
+	Runtime   types.String           `tfsdk:"runtime"`
+	TeamID    types.String           `tfsdk:"team_id"`
+	Agents    []agentDataSourceModel `tfsdk:"agents"`
+}
+
+func (d *agentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agents"
+}
+
+func (d *agentsDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches Agents from the Control Plane, optionally filtered.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": nameRegexAttribute("agents"),
+			"status": schema.StringAttribute{
+				Description: "Only return agents with this status",
+				Optional:    true,
+			},
+			"runtime": schema.StringAttribute{
+				Description: "Only return agents with this runtime (default or claude_code)",
+				Optional:    true,
+			},
+			"team_id": schema.StringAttribute{
+				Description: "Only return agents that belong to this team",
+				Optional:    true,
+			},
+			"agents": schema.ListNestedAttribute{
+				Description: "List of agents matching the filters",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: nestedDataSourceAttributes(ctx, NewAgentDataSource()),
+				},
+			},
+		},
+	}
+}
+
+func (d *agentsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *agentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data agentsDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := compileNameRegex(data.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid name_regex", err.Error())
+		return
+	}
+
+	opts := &clients.ListOptions{Filters: map[string]string{
+		"status":  data.Status.ValueString(),
+		"runtime": data.Runtime.ValueString(),
+		"team_id": data.TeamID.ValueString(),
+	}}
+
+	data.Agents = []agentDataSourceModel{}
+	for agent, err := range d.client.IterAgents(ctx, opts) {
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing agents", err.Error())
+			return
+		}
+
+		teamID := ""
+		if agent.TeamID != nil {
+			teamID = *agent.TeamID
+		}
+
+		if !matchesNameRegex(nameRegex, agent.Name) ||
+			!matchesString(data.Status, string(agent.Status)) ||
+			!matchesString(data.Runtime, string(agent.Runtime)) ||
+			!matchesString(data.TeamID, teamID) {
+			continue
+		}
+
+		var agentModel agentDataSourceModel
+		resp.Diagnostics.Append(populateAgentDataSourceModel(ctx, &agentModel, agent)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Agents = append(data.Agents, agentModel)
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
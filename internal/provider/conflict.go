@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+)
+
+// updatedAtGetter is implemented by every API object whose resource checks for concurrent changes
+type updatedAtGetter interface {
+	GetUpdatedAt() *time.Time
+}
+
+// checkNotModifiedSinceRefresh re-reads an object right before it is updated and fails when its
+// updated_at differs from the value in state, so that changes made outside Terraform since the
+// last refresh are not silently overwritten. The API has no conditional update (ETag/If-Match),
+// so the updated_at comparison is the precondition. Objects the provider already wrote in this run are
+// not checked, since their updated_at no longer matches state. get is the client method that reads the object
+// by ID, e.g. (*clients.Client).GetAgent; it is called on an uncached client.
+func checkNotModifiedSinceRefresh[T updatedAtGetter](ctx context.Context, client *clients.Client, state tfsdk.State, kind string, get func(*clients.Client, context.Context, string) (T, error)) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if client.SkipConflictCheck {
+		return diags
+	}
+
+	var id, stateUpdatedAt types.String
+	diags.Append(state.GetAttribute(ctx, path.Root("id"), &id)...)
+	diags.Append(state.GetAttribute(ctx, path.Root("updated_at"), &stateUpdatedAt)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Nothing to compare against, e.g. when the API never reported an update time
+	if stateUpdatedAt.IsNull() || stateUpdatedAt.IsUnknown() || stateUpdatedAt.ValueString() == "" {
+		return diags
+	}
+
+	// The provider's own earlier writes in this run moved updated_at, e.g. team_members moving the agent
+	if client.Wrote(id.ValueString()) {
+		return diags
+	}
+
+	object, err := get(client.Uncached(), ctx, id.ValueString())
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error checking %s for concurrent changes", kind), err.Error())
+		return diags
+	}
+
+	updatedAt := object.GetUpdatedAt()
+	if updatedAt == nil || sameTimestamp(stateUpdatedAt.ValueString(), *updatedAt) {
+		return diags
+	}
+
+	diags.AddError(
+		"Resource Modified Outside Terraform",
+		fmt.Sprintf("The %s %s was modified outside Terraform since last refresh (updated_at in state: %s, current: %s). "+
+			"Applying would overwrite those changes. Run terraform plan again to review them, "+
+			"or set skip_conflict_check = true in the provider block to disable this check.",
+			kind, id.ValueString(), stateUpdatedAt.ValueString(), updatedAt),
+	)
+
+	return diags
+}
+
+// sameTimestamp reports whether updated_at as stored in state is t. Resources store it either in the
+// format of time.Time.String or, for jobs, as RFC 3339.
+func sameTimestamp(stored string, t time.Time) bool {
+	return stored == t.String() || stored == t.Format(time.RFC3339)
+}
@@ -4,14 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
 )
 
-var _ datasource.DataSource = (*environmentDataSource)(nil)
+var (
+	_ datasource.DataSource                     = (*environmentDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*environmentDataSource)(nil)
+)
 
 func NewEnvironmentDataSource() datasource.DataSource {
 	return &environmentDataSource{}
@@ -48,11 +55,13 @@ func (d *environmentDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 		Description: "Fetches an existing Environment from the Control Plane.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Environment ID to lookup",
-				Required:    true,
+				Description: "Environment ID to lookup. Exactly one of id or name must be set",
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "Environment name",
+				Description: "Environment name to lookup. Exactly one of id or name must be set",
+				Optional:    true,
 				Computed:    true,
 			},
 			"display_name": schema.StringAttribute{
@@ -113,6 +122,15 @@ func (d *environmentDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 	}
 }
 
+func (d *environmentDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
 func (d *environmentDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -138,25 +156,48 @@ func (d *environmentDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	environment, err := d.client.GetEnvironment(config.ID.ValueString())
+	id := config.ID.ValueString()
+	if config.ID.IsNull() {
+		var err error
+		id, err = lookupEnvironmentID(ctx, d.client, config.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading environment", err.Error())
+			return
+		}
+	}
+
+	environment, err := d.client.GetEnvironment(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading environment", err.Error())
 		return
 	}
 
-	// Map response to state
-	config.Name = types.StringValue(environment.Name)
+	resp.Diagnostics.Append(populateEnvironmentDataSourceModel(ctx, &config, environment)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// populateEnvironmentDataSourceModel copies the fields of an API environment into a data source model
+func populateEnvironmentDataSourceModel(ctx context.Context, model *environmentDataSourceModel, environment *entities.Environment) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(environment.ID)
+	model.Name = types.StringValue(environment.Name)
 
 	if environment.DisplayName != nil {
-		config.DisplayName = types.StringValue(*environment.DisplayName)
+		model.DisplayName = types.StringValue(*environment.DisplayName)
 	} else {
-		config.DisplayName = types.StringNull()
+		model.DisplayName = types.StringNull()
 	}
 
 	if environment.Description != nil {
-		config.Description = types.StringValue(*environment.Description)
+		model.Description = types.StringValue(*environment.Description)
 	} else {
-		config.Description = types.StringNull()
+		model.Description = types.StringNull()
 	}
 
 	// Convert tags to list
@@ -165,67 +206,66 @@ func (d *environmentDataSource) Read(ctx context.Context, req datasource.ReadReq
 		for i, tag := range environment.Tags {
 			tagList[i] = types.StringValue(tag)
 		}
-		listVal, diags := types.ListValueFrom(ctx, types.StringType, tagList)
-		resp.Diagnostics.Append(diags...)
-		config.Tags = listVal
+		listVal, listDiags := types.ListValueFrom(ctx, types.StringType, tagList)
+		diags.Append(listDiags...)
+		model.Tags = listVal
 	} else {
-		config.Tags = types.ListNull(types.StringType)
+		model.Tags = types.ListNull(types.StringType)
 	}
 
 	// Convert settings to JSON string
 	if len(environment.Settings) > 0 {
 		settingsJSON, err := toJSONString(environment.Settings)
 		if err != nil {
-			resp.Diagnostics.AddError("Error converting settings", err.Error())
-			return
+			diags.AddError("Error converting settings", err.Error())
+			return diags
 		}
-		config.Settings = types.StringValue(settingsJSON)
+		model.Settings = types.StringValue(settingsJSON)
 	} else {
-		config.Settings = types.StringNull()
+		model.Settings = types.StringNull()
 	}
 
-	config.Status = types.StringValue(string(environment.Status))
+	model.Status = types.StringValue(string(environment.Status))
 
 	// Convert execution environment to JSON string
 	if len(environment.ExecutionEnvironment) > 0 {
 		execEnvJSON, err := toJSONString(environment.ExecutionEnvironment)
 		if err != nil {
-			resp.Diagnostics.AddError("Error converting execution_environment", err.Error())
-			return
+			diags.AddError("Error converting execution_environment", err.Error())
+			return diags
 		}
-		config.ExecutionEnvironment = types.StringValue(execEnvJSON)
+		model.ExecutionEnvironment = types.StringValue(execEnvJSON)
 	} else {
-		config.ExecutionEnvironment = types.StringNull()
+		model.ExecutionEnvironment = types.StringNull()
 	}
 
 	if environment.WorkerToken != nil {
-		config.WorkerToken = types.StringValue(*environment.WorkerToken)
+		model.WorkerToken = types.StringValue(*environment.WorkerToken)
 	} else {
-		config.WorkerToken = types.StringNull()
+		model.WorkerToken = types.StringNull()
 	}
 
 	if environment.TemporalNamespaceID != nil {
-		config.TemporalNamespaceID = types.StringValue(*environment.TemporalNamespaceID)
+		model.TemporalNamespaceID = types.StringValue(*environment.TemporalNamespaceID)
 	} else {
-		config.TemporalNamespaceID = types.StringNull()
+		model.TemporalNamespaceID = types.StringNull()
 	}
 
-	config.ActiveWorkers = types.Int64Value(int64(environment.ActiveWorkers))
-	config.IdleWorkers = types.Int64Value(int64(environment.IdleWorkers))
-	config.BusyWorkers = types.Int64Value(int64(environment.BusyWorkers))
+	model.ActiveWorkers = types.Int64Value(int64(environment.ActiveWorkers))
+	model.IdleWorkers = types.Int64Value(int64(environment.IdleWorkers))
+	model.BusyWorkers = types.Int64Value(int64(environment.BusyWorkers))
 
 	if environment.CreatedAt != nil {
-		config.CreatedAt = types.StringValue(environment.CreatedAt.String())
+		model.CreatedAt = types.StringValue(environment.CreatedAt.String())
 	} else {
-		config.CreatedAt = types.StringNull()
+		model.CreatedAt = types.StringNull()
 	}
 
 	if environment.UpdatedAt != nil {
-		config.UpdatedAt = types.StringValue(environment.UpdatedAt.String())
+		model.UpdatedAt = types.StringValue(environment.UpdatedAt.String())
 	} else {
-		config.UpdatedAt = types.StringNull()
+		model.UpdatedAt = types.StringNull()
 	}
 
-	diags = resp.State.Set(ctx, &config)
-	resp.Diagnostics.Append(diags...)
+	return diags
 }
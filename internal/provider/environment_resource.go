@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -165,7 +164,7 @@ func (r *environmentResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	// Create environment
-	environment, err := r.client.CreateEnvironment(createReq)
+	environment, err := r.client.CreateEnvironment(ctx, createReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating environment", err.Error())
 		return
@@ -209,7 +208,7 @@ func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	environment, err := r.client.GetEnvironment(state.ID.ValueString())
+	environment, err := r.client.GetEnvironment(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading environment", err.Error())
 		return
@@ -282,8 +281,13 @@ func (r *environmentResource) Update(ctx context.Context, req resource.UpdateReq
 		updateReq.Status = &status
 	}
 
+	resp.Diagnostics.Append(checkNotModifiedSinceRefresh(ctx, r.client, req.State, "environment", (*clients.Client).GetEnvironment)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Update environment
-	environment, err := r.client.UpdateEnvironment(state.ID.ValueString(), updateReq)
+	environment, err := r.client.UpdateEnvironment(ctx, state.ID.ValueString(), updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating environment", err.Error())
 		return
@@ -331,13 +335,16 @@ func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	err := r.client.DeleteEnvironment(state.ID.ValueString())
+	err := r.client.DeleteEnvironment(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting environment", err.Error())
 		return
 	}
 }
 
+// ImportState accepts either the environment ID or the environment name
 func (r *environmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByLookup(ctx, req, resp, "environment", func(name string) (string, error) {
+		return lookupEnvironmentID(ctx, r.client, name)
+	})
 }
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+)
+
+var _ datasource.DataSource = (*environmentsDataSource)(nil)
+
+func NewEnvironmentsDataSource() datasource.DataSource {
+	return &environmentsDataSource{}
+}
+
+type environmentsDataSource struct {
+	client *clients.Client
+}
+
+type environmentsDataSourceModel struct {
+	NameRegex    types.String                 `tfsdk:"name_regex"`
+	Status       types.String                 `tfsdk:"status"`
+	Tags         types.List                   `tfsdk:"tags"`
+	Environments []environmentDataSourceModel `tfsdk:"environments"`
+}
+
+func (d *environmentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environments"
+}
+
+func (d *environmentsDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches Environments from the Control Plane, optionally filtered.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": nameRegexAttribute("environments"),
+			"status": schema.StringAttribute{
+				Description: "Only return environments with this status",
+				Optional:    true,
+			},
+			"tags": schema.ListAttribute{
+				Description: "Only return environments that have all of these tags",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"environments": schema.ListNestedAttribute{
+				Description: "List of environments matching the filters",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: nestedDataSourceAttributes(ctx, NewEnvironmentDataSource()),
+				},
+			},
+		},
+	}
+}
+
+func (d *environmentsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *environmentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data environmentsDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := compileNameRegex(data.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid name_regex", err.Error())
+		return
+	}
+
+	tags, diags := tagsFilter(ctx, data.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := &clients.ListOptions{Filters: map[string]string{
+		"status": data.Status.ValueString(),
+	}}
+
+	data.Environments = []environmentDataSourceModel{}
+	for environment, err := range d.client.IterEnvironments(ctx, opts) {
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing environments", err.Error())
+			return
+		}
+
+		if !matchesNameRegex(nameRegex, environment.Name) ||
+			!matchesString(data.Status, string(environment.Status)) ||
+			!hasAllTags(environment.Tags, tags) {
+			continue
+		}
+
+		var environmentModel environmentDataSourceModel
+		resp.Diagnostics.Append(populateEnvironmentDataSourceModel(ctx, &environmentModel, environment)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Environments = append(data.Environments, environmentModel)
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // parseJSON parses a JSON string into a map
@@ -32,3 +33,13 @@ func toJSONString(data map[string]interface{}) (string, error) {
 
 	return string(bytes), nil
 }
+
+// quotedList formats names as a quoted, comma-separated list
+func quotedList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+
+	return strings.Join(quoted, ", ")
+}
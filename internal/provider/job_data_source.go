@@ -4,14 +4,19 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-kubiya-control-plane/internal/clients"
 )
 
-var _ datasource.DataSource = (*jobDataSource)(nil)
+var (
+	_ datasource.DataSource                     = (*jobDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*jobDataSource)(nil)
+)
 
 func NewJobDataSource() datasource.DataSource {
 	return &jobDataSource{}
@@ -52,11 +57,13 @@ func (d *jobDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 		Description: "Fetches a Job from the Control Plane by ID.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Job ID",
-				Required:    true,
+				Description: "Job ID to lookup. Exactly one of id or name must be set",
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "Job name",
+				Description: "Job name to lookup. Exactly one of id or name must be set",
+				Optional:    true,
 				Computed:    true,
 			},
 			"description": schema.StringAttribute{
@@ -131,6 +138,15 @@ func (d *jobDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 	}
 }
 
+func (d *jobDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
 func (d *jobDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -156,7 +172,17 @@ func (d *jobDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		return
 	}
 
-	job, err := d.client.GetJob(config.ID.ValueString())
+	id := config.ID.ValueString()
+	if config.ID.IsNull() {
+		var err error
+		id, err = lookupJobID(ctx, d.client, config.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading job", err.Error())
+			return
+		}
+	}
+
+	job, err := d.client.GetJob(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading job", err.Error())
 		return
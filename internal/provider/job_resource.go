@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -296,7 +295,7 @@ func (r *jobResource) Create(ctx context.Context, req resource.CreateRequest, re
 		}
 	}
 
-	job, err := r.client.CreateJob(createReq)
+	job, err := r.client.CreateJob(ctx, createReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating job", err.Error())
 		return
@@ -317,7 +316,7 @@ func (r *jobResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
-	job, err := r.client.GetJob(state.ID.ValueString())
+	job, err := r.client.GetJob(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading job", err.Error())
 		return
@@ -441,7 +440,12 @@ func (r *jobResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		}
 	}
 
-	job, err := r.client.UpdateJob(plan.ID.ValueString(), updateReq)
+	resp.Diagnostics.Append(checkNotModifiedSinceRefresh(ctx, r.client, req.State, "job", (*clients.Client).GetJob)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	job, err := r.client.UpdateJob(ctx, plan.ID.ValueString(), updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating job", err.Error())
 		return
@@ -461,15 +465,18 @@ func (r *jobResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
-	err := r.client.DeleteJob(state.ID.ValueString())
+	err := r.client.DeleteJob(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting job", err.Error())
 		return
 	}
 }
 
+// ImportState accepts either the job ID or the job name
 func (r *jobResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByLookup(ctx, req, resp, "job", func(name string) (string, error) {
+		return lookupJobID(ctx, r.client, name)
+	})
 }
 
 func (r *jobResource) updateModelFromJob(model *jobResourceModel, job *entities.Job) {
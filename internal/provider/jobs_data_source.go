@@ -146,14 +146,13 @@ func (d *jobsDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	jobs, err := d.client.ListJobs()
-	if err != nil {
-		resp.Diagnostics.AddError("Error listing jobs", err.Error())
-		return
-	}
+	data.Jobs = []jobDataSourceModel{}
+	for job, err := range d.client.IterJobs(ctx, nil) {
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing jobs", err.Error())
+			return
+		}
 
-	data.Jobs = make([]jobDataSourceModel, 0, len(jobs))
-	for _, job := range jobs {
 		jobModel := jobDataSourceModel{
 			ID:             types.StringValue(job.ID),
 			Name:           types.StringValue(job.Name),
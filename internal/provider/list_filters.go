@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// nameRegexAttribute returns the name_regex filter attribute shared by the plural data sources
+func nameRegexAttribute(kind string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: fmt.Sprintf("Only return %s whose name matches this regular expression (RE2 syntax)", kind),
+		Optional:    true,
+		Validators:  []validator.String{regexValidator{}},
+	}
+}
+
+// nestedDataSourceAttributes turns the schema of a singular data source into the computed attributes of
+// the objects listed by its plural counterpart, so both always share one model
+func nestedDataSourceAttributes(ctx context.Context, ds datasource.DataSource) map[string]schema.Attribute {
+	var resp datasource.SchemaResponse
+	ds.Schema(ctx, datasource.SchemaRequest{}, &resp)
+
+	attributes := make(map[string]schema.Attribute, len(resp.Schema.Attributes))
+	for name, attribute := range resp.Schema.Attributes {
+		if s, ok := attribute.(schema.StringAttribute); ok {
+			s.Required = false
+			s.Optional = false
+			s.Computed = true
+			s.Validators = nil
+			attribute = s
+		}
+		attributes[name] = attribute
+	}
+
+	return attributes
+}
+
+// compileNameRegex compiles the name_regex filter; a null filter yields a nil expression
+func compileNameRegex(filter types.String) (*regexp.Regexp, error) {
+	if filter.IsNull() || filter.ValueString() == "" {
+		return nil, nil
+	}
+
+	return regexp.Compile(filter.ValueString())
+}
+
+// matchesNameRegex reports whether name matches the compiled name_regex filter, if any
+func matchesNameRegex(re *regexp.Regexp, name string) bool {
+	return re == nil || re.MatchString(name)
+}
+
+// matchesString reports whether value equals the filter; a null filter matches everything
+func matchesString(filter types.String, value string) bool {
+	return filter.IsNull() || filter.ValueString() == value
+}
+
+// matchesBool reports whether value equals the filter; a null filter matches everything
+func matchesBool(filter types.Bool, value bool) bool {
+	return filter.IsNull() || filter.ValueBool() == value
+}
+
+// boolFilterValue formats a bool filter as a query parameter value; a null filter yields an empty string
+func boolFilterValue(filter types.Bool) string {
+	if filter.IsNull() || filter.IsUnknown() {
+		return ""
+	}
+
+	return strconv.FormatBool(filter.ValueBool())
+}
+
+// tagsFilter converts the tags filter to a slice; a null filter yields an empty slice
+func tagsFilter(ctx context.Context, filter types.List) ([]string, diag.Diagnostics) {
+	var tags []string
+	if filter.IsNull() || filter.IsUnknown() {
+		return tags, nil
+	}
+
+	diags := filter.ElementsAs(ctx, &tags, false)
+	return tags, diags
+}
+
+// hasAllTags reports whether tags contains every tag in want
+func hasAllTags(tags, want []string) bool {
+	present := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		present[tag] = true
+	}
+
+	for _, tag := range want {
+		if !present[tag] {
+			return false
+		}
+	}
+
+	return true
+}
+
+var _ validator.String = regexValidator{}
+
+// regexValidator checks that a string is a valid regular expression
+type regexValidator struct{}
+
+func (v regexValidator) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Regular Expression", err.Error())
+	}
+}
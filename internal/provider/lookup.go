@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
+)
+
+// uuidPattern matches the UUIDs the Control Plane API uses as object IDs
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// isUUID reports whether s looks like an object ID rather than a name
+func isUUID(s string) bool {
+	return uuidPattern.MatchString(s)
+}
+
+// importStateByLookup imports a resource from either its ID or a friendly identifier.
+// Identifiers that are not UUIDs are resolved to an ID with lookup.
+func importStateByLookup(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, kind string, lookup func(string) (string, error)) {
+	if isUUID(req.ID) {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	id, err := lookup(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Error importing %s", kind), fmt.Sprintf("Could not resolve import ID %q: %s", req.ID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// findUniqueID returns the ID of the only item whose field equals want.
+// It fails when no item matches or when the match is ambiguous.
+func findUniqueID[T any](kind, field, want string, items []T, id func(T) string, value func(T) string) (string, error) {
+	var matches []string
+	for _, item := range items {
+		if value(item) == want {
+			matches = append(matches, id(item))
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no %s found with %s %q", kind, field, want)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%s %q is ambiguous: it matches %d %s objects (IDs: %s); use the ID instead",
+			field, want, len(matches), kind, strings.Join(matches, ", "))
+	}
+}
+
+// lookupAgentID resolves an agent name to its ID
+func lookupAgentID(ctx context.Context, client *clients.Client, name string) (string, error) {
+	agents, err := client.ListAgents(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return findUniqueID("agent", "name", name, agents,
+		func(a *entities.Agent) string { return a.ID },
+		func(a *entities.Agent) string { return a.Name })
+}
+
+// lookupTeamID resolves a team name to its ID
+func lookupTeamID(ctx context.Context, client *clients.Client, name string) (string, error) {
+	teams, err := client.ListTeams(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return findUniqueID("team", "name", name, teams,
+		func(t *entities.Team) string { return t.ID },
+		func(t *entities.Team) string { return t.Name })
+}
+
+// lookupProjectIDByKey resolves a project key to its ID
+func lookupProjectIDByKey(ctx context.Context, client *clients.Client, key string) (string, error) {
+	projects, err := client.ListProjects(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return findUniqueID("project", "key", key, projects,
+		func(p *entities.Project) string { return p.ID },
+		func(p *entities.Project) string { return p.Key })
+}
+
+// lookupProjectIDByName resolves a project name to its ID
+func lookupProjectIDByName(ctx context.Context, client *clients.Client, name string) (string, error) {
+	projects, err := client.ListProjects(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return findUniqueID("project", "name", name, projects,
+		func(p *entities.Project) string { return p.ID },
+		func(p *entities.Project) string { return p.Name })
+}
+
+// lookupEnvironmentID resolves an environment name to its ID
+func lookupEnvironmentID(ctx context.Context, client *clients.Client, name string) (string, error) {
+	environments, err := client.ListEnvironments(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return findUniqueID("environment", "name", name, environments,
+		func(e *entities.Environment) string { return e.ID },
+		func(e *entities.Environment) string { return e.Name })
+}
+
+// lookupSkillID resolves a skill name to its ID
+func lookupSkillID(ctx context.Context, client *clients.Client, name string) (string, error) {
+	skills, err := client.ListSkills(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return findUniqueID("skill", "name", name, skills,
+		func(s *entities.Skill) string { return s.ID },
+		func(s *entities.Skill) string { return s.Name })
+}
+
+// lookupPolicyID resolves a policy name to its ID
+func lookupPolicyID(ctx context.Context, client *clients.Client, name string) (string, error) {
+	policies, err := client.ListPolicies(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return findUniqueID("policy", "name", name, policies,
+		func(p *entities.Policy) string { return p.ID },
+		func(p *entities.Policy) string { return p.Name })
+}
+
+// lookupJobID resolves a job name to its ID
+func lookupJobID(ctx context.Context, client *clients.Client, name string) (string, error) {
+	jobs, err := client.ListJobs(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return findUniqueID("job", "name", name, jobs,
+		func(j *entities.Job) string { return j.ID },
+		func(j *entities.Job) string { return j.Name })
+}
+
+// lookupWorkerQueueID resolves a worker queue name within an environment to its ID
+func lookupWorkerQueueID(ctx context.Context, client *clients.Client, environmentID, name string) (string, error) {
+	queues, err := client.ListWorkerQueues(ctx, environmentID)
+	if err != nil {
+		return "", err
+	}
+
+	return findUniqueID("worker queue", "name", name, queues,
+		func(q *entities.WorkerQueue) string { return q.ID },
+		func(q *entities.WorkerQueue) string { return q.Name })
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/pkg/controlplane"
+	"terraform-provider-kubiya-control-plane/pkg/fakeserver"
+)
+
+// fakeServerClients starts a fake Control Plane and returns a provider client and an SDK client for it
+func fakeServerClients(t *testing.T) (*clients.Client, *controlplane.Client) {
+	t.Helper()
+
+	server := httptest.NewServer(fakeserver.New())
+	t.Cleanup(server.Close)
+
+	t.Setenv("KUBIYA_CONTROL_PLANE_BASE_URL", server.URL)
+	client, err := clients.New("test-api-key")
+	require.NoError(t, err)
+
+	sdk, err := controlplane.New(controlplane.WithAPIKey("test-api-key"), controlplane.WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	return client, sdk
+}
+
+// importState imports r from importID and returns the resulting id attribute
+func importState(t *testing.T, r resource.ResourceWithImportState, importID string) (string, *resource.ImportStateResponse) {
+	t.Helper()
+
+	ctx := context.Background()
+	resp := &resource.ImportStateResponse{State: resourceState(t, r, nil)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: importID}, resp)
+
+	var id string
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	}
+
+	return id, resp
+}
+
+func TestFindUniqueID(t *testing.T) {
+	type item struct{ id, name string }
+	items := []item{{"t1", "alpha"}, {"t2", "beta"}, {"t3", "beta"}}
+	find := func(want string) (string, error) {
+		return findUniqueID("team", "name", want, items,
+			func(i item) string { return i.id },
+			func(i item) string { return i.name })
+	}
+
+	id, err := find("alpha")
+	require.NoError(t, err)
+	assert.Equal(t, "t1", id)
+
+	_, err = find("gamma")
+	require.Error(t, err)
+	assert.Equal(t, `no team found with name "gamma"`, err.Error())
+
+	_, err = find("beta")
+	require.Error(t, err)
+	assert.Equal(t, `name "beta" is ambiguous: it matches 2 team objects (IDs: t2, t3); use the ID instead`, err.Error())
+}
+
+func TestImportStateByLookup(t *testing.T) {
+	ctx := context.Background()
+	client, sdk := fakeServerClients(t)
+
+	project, err := sdk.CreateProject(ctx, &controlplane.ProjectCreateRequest{Name: "platform", Key: "PLAT"})
+	require.NoError(t, err)
+
+	prod, err := sdk.CreateEnvironment(ctx, &controlplane.EnvironmentCreateRequest{Name: "prod"})
+	require.NoError(t, err)
+	queue, err := sdk.CreateWorkerQueue(ctx, prod.ID, &controlplane.WorkerQueueCreateRequest{Name: "gpu"})
+	require.NoError(t, err)
+
+	// Queues of the same name in other environments are told apart by the environment
+	staging, err := sdk.CreateEnvironment(ctx, &controlplane.EnvironmentCreateRequest{Name: "staging"})
+	require.NoError(t, err)
+	_, err = sdk.CreateWorkerQueue(ctx, staging.ID, &controlplane.WorkerQueueCreateRequest{Name: "gpu"})
+	require.NoError(t, err)
+
+	// Two environments share this name
+	for range 2 {
+		_, err = sdk.CreateEnvironment(ctx, &controlplane.EnvironmentCreateRequest{Name: "shared"})
+		require.NoError(t, err)
+	}
+
+	projects := &projectResource{client: client}
+	queues := &workerQueueResource{client: client}
+
+	t.Run("project key", func(t *testing.T) {
+		id, resp := importState(t, projects, "PLAT")
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		assert.Equal(t, project.ID, id)
+	})
+
+	t.Run("project ID", func(t *testing.T) {
+		id, resp := importState(t, projects, project.ID)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		assert.Equal(t, project.ID, id)
+	})
+
+	t.Run("project not found", func(t *testing.T) {
+		_, resp := importState(t, projects, "NOPE")
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Error importing project", resp.Diagnostics.Errors()[0].Summary())
+		assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), `no project found with key "NOPE"`)
+	})
+
+	t.Run("worker queue by environment and name", func(t *testing.T) {
+		id, resp := importState(t, queues, "prod/gpu")
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		assert.Equal(t, queue.ID, id)
+	})
+
+	for importID, want := range map[string]string{
+		"gpu":        "expected a worker queue ID or <environment_name>/<queue_name>",
+		"prod/":      "expected a worker queue ID or <environment_name>/<queue_name>",
+		"prod/cpu":   `no worker queue found with name "cpu"`,
+		"dev/gpu":    `no environment found with name "dev"`,
+		"shared/gpu": `name "shared" is ambiguous: it matches 2 environment objects`,
+	} {
+		t.Run("worker queue "+importID, func(t *testing.T) {
+			_, resp := importState(t, queues, importID)
+			require.True(t, resp.Diagnostics.HasError())
+			assert.Equal(t, "Error importing worker queue", resp.Diagnostics.Errors()[0].Summary())
+			assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), want)
+		})
+	}
+}
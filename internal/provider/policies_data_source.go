@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+)
+
+var _ datasource.DataSource = (*policiesDataSource)(nil)
+
+func NewPoliciesDataSource() datasource.DataSource {
+	return &policiesDataSource{}
+}
+
+type policiesDataSource struct {
+	client *clients.Client
+}
+
+type policiesDataSourceModel struct {
+	NameRegex  types.String            `tfsdk:"name_regex"`
+	PolicyType types.String            `tfsdk:"policy_type"`
+	Enabled    types.Bool              `tfsdk:"enabled"`
+	Tags       types.List              `tfsdk:"tags"`
+	Policies   []policyDataSourceModel `tfsdk:"policies"`
+}
+
+func (d *policiesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policies"
+}
+
+func (d *policiesDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches Policies from the Control Plane, optionally filtered.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": nameRegexAttribute("policies"),
+			"policy_type": schema.StringAttribute{
+				Description: "Only return policies of this type",
+				Optional:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Only return policies that are enabled (true) or disabled (false)",
+				Optional:    true,
+			},
+			"tags": schema.ListAttribute{
+				Description: "Only return policies that have all of these tags",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"policies": schema.ListNestedAttribute{
+				Description: "List of policies matching the filters",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: nestedDataSourceAttributes(ctx, NewPolicyDataSource()),
+				},
+			},
+		},
+	}
+}
+
+func (d *policiesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *policiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data policiesDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := compileNameRegex(data.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid name_regex", err.Error())
+		return
+	}
+
+	tags, diags := tagsFilter(ctx, data.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := &clients.ListOptions{Filters: map[string]string{
+		"policy_type": data.PolicyType.ValueString(),
+		"enabled":     boolFilterValue(data.Enabled),
+	}}
+
+	data.Policies = []policyDataSourceModel{}
+	for policy, err := range d.client.IterPolicies(ctx, opts) {
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing policies", err.Error())
+			return
+		}
+
+		if !matchesNameRegex(nameRegex, policy.Name) ||
+			!matchesString(data.PolicyType, string(policy.PolicyType)) ||
+			!matchesBool(data.Enabled, policy.Enabled) ||
+			!hasAllTags(policy.Tags, tags) {
+			continue
+		}
+
+		var policyModel policyDataSourceModel
+		resp.Diagnostics.Append(populatePolicyDataSourceModel(ctx, &policyModel, policy)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Policies = append(data.Policies, policyModel)
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
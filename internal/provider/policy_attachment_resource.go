@@ -0,0 +1,249 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
+	"terraform-provider-kubiya-control-plane/pkg/controlplane"
+)
+
+// policyAttachmentTargetTypes lists the kinds of objects a policy can be attached to
+var policyAttachmentTargetTypes = []string{
+	string(entities.PolicyEntityAgent),
+	string(entities.PolicyEntityTeam),
+	string(entities.PolicyEntityEnvironment),
+	string(entities.PolicyEntityProject),
+}
+
+var (
+	_ resource.Resource                   = (*policyAttachmentResource)(nil)
+	_ resource.ResourceWithImportState    = (*policyAttachmentResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*policyAttachmentResource)(nil)
+)
+
+func NewPolicyAttachmentResource() resource.Resource {
+	return &policyAttachmentResource{}
+}
+
+type policyAttachmentResource struct {
+	client *clients.Client
+}
+
+type policyAttachmentResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	PolicyID   types.String `tfsdk:"policy_id"`
+	TargetType types.String `tfsdk:"target_type"`
+	TargetID   types.String `tfsdk:"target_id"`
+	CreatedAt  types.String `tfsdk:"created_at"`
+}
+
+func (r *policyAttachmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_attachment"
+}
+
+func (r *policyAttachmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Attaches a Policy to an agent, team, environment or project. Each attachment is managed on its own, so different configurations can attach policies to the same target.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Attachment ID",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policy_id": schema.StringAttribute{
+				Description: "ID of the policy to attach",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_type": schema.StringAttribute{
+				Description: "Kind of object the policy is attached to (" + strings.Join(policyAttachmentTargetTypes, ", ") + ")",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_id": schema.StringAttribute{
+				Description: "ID of the agent, team, environment or project the policy is attached to",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Timestamp when the policy was attached",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *policyAttachmentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var targetType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("target_type"), &targetType)...)
+	if resp.Diagnostics.HasError() || targetType.IsNull() || targetType.IsUnknown() {
+		return
+	}
+
+	if !slices.Contains(policyAttachmentTargetTypes, targetType.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("target_type"), "Invalid Target Type",
+			fmt.Sprintf("target_type must be one of %s, got %q", quotedList(policyAttachmentTargetTypes), targetType.ValueString()))
+	}
+}
+
+func (r *policyAttachmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *policyAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan policyAttachmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	association, err := r.client.CreatePolicyAssociation(ctx, &entities.PolicyAssociationCreateRequest{
+		PolicyID:   plan.PolicyID.ValueString(),
+		EntityType: entities.PolicyEntityType(plan.TargetType.ValueString()),
+		EntityID:   plan.TargetID.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error attaching policy", err.Error())
+		return
+	}
+
+	populatePolicyAttachmentModel(&plan, association)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *policyAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state policyAttachmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	association, err := r.client.GetPolicyAssociation(ctx, state.ID.ValueString())
+	if err != nil {
+		// A policy detached outside Terraform is planned to be attached again
+		if controlplane.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading policy attachment", err.Error())
+		return
+	}
+
+	populatePolicyAttachmentModel(&state, association)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only stores the plan: every configurable attribute forces a new attachment
+func (r *policyAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan policyAttachmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *policyAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state policyAttachmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Detaching changes the policy and its target too, which may be updated later in this apply
+	r.client.MarkWritten(state.PolicyID.ValueString(), state.TargetID.ValueString())
+	err := r.client.DeletePolicyAssociation(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error detaching policy", err.Error())
+		return
+	}
+}
+
+// ImportState accepts either the attachment ID or <target_type>/<target_id>/<policy_id>
+func (r *policyAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateByLookup(ctx, req, resp, "policy attachment", func(importID string) (string, error) {
+		parts := strings.Split(importID, "/")
+		if len(parts) != 3 || slices.Contains(parts, "") {
+			return "", fmt.Errorf("expected a policy attachment ID or <target_type>/<target_id>/<policy_id>")
+		}
+
+		opts := &clients.ListOptions{Filters: map[string]string{
+			"entity_type": parts[0],
+			"entity_id":   parts[1],
+			"policy_id":   parts[2],
+		}}
+
+		var associations []*entities.PolicyAssociation
+		for association, err := range r.client.IterPolicyAssociations(ctx, opts) {
+			if err != nil {
+				return "", err
+			}
+			associations = append(associations, association)
+		}
+
+		return findUniqueID("policy attachment", "target", importID, associations,
+			func(a *entities.PolicyAssociation) string { return a.ID },
+			func(a *entities.PolicyAssociation) string {
+				return fmt.Sprintf("%s/%s/%s", a.EntityType, a.EntityID, a.PolicyID)
+			})
+	})
+}
+
+// populatePolicyAttachmentModel copies the fields of an API policy association into a resource model
+func populatePolicyAttachmentModel(model *policyAttachmentResourceModel, association *entities.PolicyAssociation) {
+	model.ID = types.StringValue(association.ID)
+	model.PolicyID = types.StringValue(association.PolicyID)
+	model.TargetType = types.StringValue(string(association.EntityType))
+	model.TargetID = types.StringValue(association.EntityID)
+
+	if association.CreatedAt != nil {
+		model.CreatedAt = types.StringValue(association.CreatedAt.String())
+	} else {
+		model.CreatedAt = types.StringNull()
+	}
+}
@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyAttachmentReadNotFound(t *testing.T) {
+	r := &policyAttachmentResource{client: notFoundClient(t)}
+	state := resourceState(t, r, map[string]string{"id": "pa1", "policy_id": "p1", "target_type": "agent", "target_id": "a1"})
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull(), "an attachment deleted outside Terraform is removed from state")
+}
@@ -0,0 +1,577 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
+)
+
+// policyBundleTagPrefix marks the policies a bundle owns; the bundle name follows the prefix
+const policyBundleTagPrefix = "terraform-bundle:"
+
+var (
+	_ resource.Resource                   = (*policyBundleResource)(nil)
+	_ resource.ResourceWithImportState    = (*policyBundleResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*policyBundleResource)(nil)
+	_ resource.ResourceWithModifyPlan     = (*policyBundleResource)(nil)
+)
+
+func NewPolicyBundleResource() resource.Resource {
+	return &policyBundleResource{}
+}
+
+type policyBundleResource struct {
+	client *clients.Client
+}
+
+type policyBundleResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	PathGlob  types.String `tfsdk:"path_glob"`
+	Policies  types.Map    `tfsdk:"policies"`
+	PolicyIDs types.Map    `tfsdk:"policy_ids"`
+}
+
+type policyBundleEntryModel struct {
+	Content types.String `tfsdk:"content"`
+	Tags    types.List   `tfsdk:"tags"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+var policyBundleEntryAttrTypes = map[string]attr.Type{
+	"content": types.StringType,
+	"tags":    types.ListType{ElemType: types.StringType},
+	"enabled": types.BoolType,
+}
+
+func (r *policyBundleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_bundle"
+}
+
+func (r *policyBundleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a set of Rego policies as a unit. Policies are created, updated and deleted to match the bundle, and policies removed from it are deleted.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Bundle ID, the same as its name",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Bundle name. The bundle owns every policy tagged " + policyBundleTagPrefix + "<name>",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path_glob": schema.StringAttribute{
+				Description: "Glob matching the .rego files to sync, e.g. \"${path.module}/policies/*.rego\". Each file becomes a policy named after the file without its extension; _test.rego files are skipped. Exactly one of path_glob or policies must be set",
+				Optional:    true,
+			},
+			"policies": schema.MapNestedAttribute{
+				Description: "Policies in the bundle, keyed by policy name. Computed from the files when path_glob is set. Exactly one of path_glob or policies must be set",
+				Optional:    true,
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"content": schema.StringAttribute{
+							Description: "OPA Rego policy content",
+							Required:    true,
+						},
+						"tags": schema.ListAttribute{
+							Description: "Policy tags, in addition to the ownership tag",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"enabled": schema.BoolAttribute{
+							Description: "Whether the policy is enabled. Default: true",
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(true),
+						},
+					},
+				},
+			},
+			"policy_ids": schema.MapAttribute{
+				Description: "IDs of the policies in the bundle, keyed by policy name",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *policyBundleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig requires exactly one source of policies and checks the Rego of configured policies
+func (r *policyBundleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config policyBundleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.PathGlob.IsUnknown() || config.Policies.IsUnknown() {
+		return
+	}
+
+	if config.PathGlob.IsNull() == config.Policies.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("policies"), "Invalid Attribute Combination",
+			`Exactly one of "path_glob" or "policies" must be set`)
+		return
+	}
+
+	if config.Policies.IsNull() {
+		return
+	}
+
+	entries := map[string]policyBundleEntryModel{}
+	resp.Diagnostics.Append(config.Policies.ElementsAs(ctx, &entries, false)...)
+	for name, entry := range entries {
+		if entry.Content.IsNull() || entry.Content.IsUnknown() {
+			continue
+		}
+		contentPath := path.Root("policies").AtMapKey(name).AtName("content")
+		resp.Diagnostics.Append(validatePolicyContent(contentPath, string(entities.PolicyTypeRego), entry.Content.ValueString())...)
+	}
+}
+
+// ModifyPlan loads the files matched by path_glob into policies, so that the plan shows a diff per
+// policy, and keeps policy_ids known while the set of policies is unchanged
+func (r *policyBundleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan policyBundleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.PathGlob.IsNull() && !plan.PathGlob.IsUnknown() {
+		policies, diags := policyBundleFilesValue(ctx, plan.PathGlob.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.Policies = policies
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state policyBundleResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if plan.Policies.Equal(state.Policies) {
+			plan.PolicyIDs = state.PolicyIDs
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+func (r *policyBundleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan policyBundleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *policyBundleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state policyBundleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owned, _, _, err := r.listBundlePolicies(ctx, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading policy bundle", err.Error())
+		return
+	}
+
+	prior := map[string]policyBundleEntryModel{}
+	if !state.Policies.IsNull() && !state.Policies.IsUnknown() {
+		resp.Diagnostics.Append(state.Policies.ElementsAs(ctx, &prior, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	entries := make(map[string]policyBundleEntryModel, len(owned))
+	ids := make(map[string]string, len(owned))
+	for name, policy := range owned {
+		tags, diags := policyBundleTagsValue(ctx, state.Name.ValueString(), policy.Tags, prior[name].Tags)
+		resp.Diagnostics.Append(diags...)
+		entries[name] = policyBundleEntryModel{
+			Content: types.StringValue(policy.PolicyContent),
+			Tags:    tags,
+			Enabled: types.BoolValue(policy.Enabled),
+		}
+		ids[name] = policy.ID
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ID = state.Name
+	state.Policies, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: policyBundleEntryAttrTypes}, entries)
+	resp.Diagnostics.Append(diags...)
+	state.PolicyIDs, diags = types.MapValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *policyBundleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan policyBundleResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *policyBundleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state policyBundleResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owned, _, duplicates, err := r.listBundlePolicies(ctx, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting policy bundle", err.Error())
+		return
+	}
+
+	for _, policy := range append(duplicates, sortedPolicies(owned)...) {
+		if err := r.client.DeletePolicy(ctx, policy.ID); err != nil {
+			resp.Diagnostics.AddError("Error deleting policy bundle", fmt.Sprintf("failed to delete policy %q: %s", policy.Name, err))
+			return
+		}
+	}
+}
+
+// ImportState accepts the bundle name. The imported bundle lists its policies inline; switch the
+// configuration to path_glob afterwards if the policies live in files.
+func (r *policyBundleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// reconcile creates, updates and deletes the bundle's policies to match the model, and records their
+// IDs in it. Name conflicts with policies the bundle does not own are checked before anything is
+// changed. A failure part way leaves the policies changed so far tagged, so the next apply picks them
+// up again.
+func (r *policyBundleResource) reconcile(ctx context.Context, model *policyBundleResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// A glob that was unknown during plan is read now
+	if model.Policies.IsUnknown() && !model.PathGlob.IsNull() {
+		policies, d := policyBundleFilesValue(ctx, model.PathGlob.ValueString())
+		diags.Append(d...)
+		if diags.HasError() {
+			return diags
+		}
+		model.Policies = policies
+	}
+
+	bundle := model.Name.ValueString()
+	desired := map[string]policyBundleEntryModel{}
+	diags.Append(model.Policies.ElementsAs(ctx, &desired, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	owned, others, duplicates, err := r.listBundlePolicies(ctx, bundle)
+	if err != nil {
+		diags.AddError("Error listing policies", err.Error())
+		return diags
+	}
+
+	var conflicts []string
+	for name := range desired {
+		if _, ok := owned[name]; !ok && others[name] {
+			conflicts = append(conflicts, name)
+		}
+	}
+	if len(conflicts) > 0 {
+		slices.Sort(conflicts)
+		diags.AddAttributeError(path.Root("policies"), "Policy Name Conflict",
+			fmt.Sprintf("Policies named %s already exist and are not owned by bundle %q. Delete or rename them, or tag them %s to adopt them.",
+				quotedList(conflicts), bundle, policyBundleTag(bundle)))
+		return diags
+	}
+
+	ids := make(map[string]string, len(desired))
+	for _, name := range slices.Sorted(maps.Keys(desired)) {
+		entry := desired[name]
+
+		var tags []string
+		if !entry.Tags.IsNull() {
+			diags.Append(entry.Tags.ElementsAs(ctx, &tags, false)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+		tags = append(tags, policyBundleTag(bundle))
+
+		content := entry.Content.ValueString()
+		enabled := entry.Enabled.ValueBool()
+
+		existing, ok := owned[name]
+		switch {
+		case !ok:
+			policy, err := r.client.CreatePolicy(ctx, &entities.PolicyCreateRequest{
+				Name:          name,
+				PolicyContent: content,
+				PolicyType:    entities.PolicyTypeRego,
+				Enabled:       enabled,
+				Tags:          tags,
+			})
+			if err != nil {
+				diags.AddError("Error creating policy", fmt.Sprintf("policy %q: %s", name, err))
+				return diags
+			}
+			ids[name] = policy.ID
+		case existing.PolicyContent != content || existing.Enabled != enabled || !sameTags(existing.Tags, tags):
+			policy, err := r.client.UpdatePolicy(ctx, existing.ID, &entities.PolicyUpdateRequest{
+				PolicyContent: &content,
+				Enabled:       &enabled,
+				Tags:          tags,
+			})
+			if err != nil {
+				diags.AddError("Error updating policy", fmt.Sprintf("policy %q: %s", name, err))
+				return diags
+			}
+			ids[name] = policy.ID
+		default:
+			ids[name] = existing.ID
+		}
+	}
+
+	for _, policy := range append(duplicates, sortedPolicies(owned)...) {
+		if _, ok := desired[policy.Name]; ok && ids[policy.Name] == policy.ID {
+			continue
+		}
+		if err := r.client.DeletePolicy(ctx, policy.ID); err != nil {
+			diags.AddError("Error deleting policy", fmt.Sprintf("policy %q: %s", policy.Name, err))
+			return diags
+		}
+	}
+
+	model.ID = model.Name
+	policyIDs, d := types.MapValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+	model.PolicyIDs = policyIDs
+
+	return diags
+}
+
+// listBundlePolicies lists the organization's policies. It returns the policies the bundle owns by
+// name, the names of the policies it does not own, and any further owned policies sharing a name,
+// which the bundle deletes.
+func (r *policyBundleResource) listBundlePolicies(ctx context.Context, bundle string) (map[string]*entities.Policy, map[string]bool, []*entities.Policy, error) {
+	tag := policyBundleTag(bundle)
+	owned := map[string]*entities.Policy{}
+	others := map[string]bool{}
+	var duplicates []*entities.Policy
+
+	for policy, err := range r.client.IterPolicies(ctx, nil) {
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		switch {
+		case !slices.Contains(policy.Tags, tag):
+			others[policy.Name] = true
+		case owned[policy.Name] != nil:
+			duplicates = append(duplicates, policy)
+		default:
+			owned[policy.Name] = policy
+		}
+	}
+
+	return owned, others, duplicates, nil
+}
+
+// policyBundleTag returns the tag marking the policies owned by a bundle
+func policyBundleTag(bundle string) string {
+	return policyBundleTagPrefix + bundle
+}
+
+// policyBundleTagsValue converts the tags of an owned policy to its tags attribute, leaving out the
+// ownership tag. The prior value is kept when it holds the same tags, so that ordering and an empty
+// list versus null never show up as drift.
+func policyBundleTagsValue(ctx context.Context, bundle string, tags []string, prior types.List) (types.List, diag.Diagnostics) {
+	var own []string
+	for _, tag := range tags {
+		if tag != policyBundleTag(bundle) {
+			own = append(own, tag)
+		}
+	}
+
+	if !prior.IsNull() && !prior.IsUnknown() {
+		var priorTags []string
+		diags := prior.ElementsAs(ctx, &priorTags, false)
+		if !diags.HasError() && sameTags(priorTags, own) {
+			return prior, nil
+		}
+	}
+
+	if len(own) == 0 && (prior.IsNull() || prior.IsUnknown()) {
+		return types.ListNull(types.StringType), nil
+	}
+
+	return types.ListValueFrom(ctx, types.StringType, own)
+}
+
+// sameTags reports whether two tag lists hold the same tags, ignoring order
+func sameTags(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// sortedPolicies returns the policies of a map ordered by name
+func sortedPolicies(policies map[string]*entities.Policy) []*entities.Policy {
+	sorted := make([]*entities.Policy, 0, len(policies))
+	for _, name := range slices.Sorted(maps.Keys(policies)) {
+		sorted = append(sorted, policies[name])
+	}
+
+	return sorted
+}
+
+// policyBundleFile is a Rego module read from disk for a bundle
+type policyBundleFile struct {
+	path    string
+	content string
+}
+
+// policyBundleFilesValue reads the files matched by a glob into the policies attribute, checking
+// their Rego on the way
+func policyBundleFilesValue(ctx context.Context, pattern string) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	policiesType := types.ObjectType{AttrTypes: policyBundleEntryAttrTypes}
+
+	files, err := readPolicyBundleFiles(pattern)
+	if err != nil {
+		diags.AddAttributeError(path.Root("path_glob"), "Error reading policy files", err.Error())
+		return types.MapUnknown(policiesType), diags
+	}
+
+	entries := make(map[string]policyBundleEntryModel, len(files))
+	for name, file := range files {
+		for _, problem := range checkRegoPolicy(file.content) {
+			diags.AddAttributeError(path.Root("path_glob"), "Invalid Rego Policy", fmt.Sprintf("%s: %s", file.path, problem))
+		}
+		entries[name] = policyBundleEntryModel{
+			Content: types.StringValue(file.content),
+			Tags:    types.ListNull(types.StringType),
+			Enabled: types.BoolValue(true),
+		}
+	}
+	if diags.HasError() {
+		return types.MapUnknown(policiesType), diags
+	}
+
+	policies, d := types.MapValueFrom(ctx, policiesType, entries)
+	diags.Append(d...)
+
+	return policies, diags
+}
+
+// readPolicyBundleFiles reads the .rego files matching a glob, keyed by policy name: the file name
+// without its extension. Test modules (_test.rego) are skipped.
+func readPolicyBundleFiles(pattern string) (map[string]policyBundleFile, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+	}
+
+	files := map[string]policyBundleFile{}
+	for _, match := range matches {
+		base := filepath.Base(match)
+		if filepath.Ext(base) != ".rego" || strings.HasSuffix(base, "_test.rego") {
+			continue
+		}
+
+		name := strings.TrimSuffix(base, ".rego")
+		if existing, ok := files[name]; ok {
+			return nil, fmt.Errorf("%s and %s would both create policy %q", existing.path, match, name)
+		}
+
+		content, err := os.ReadFile(match)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = policyBundleFile{path: match, content: string(content)}
+	}
+
+	return files, nil
+}
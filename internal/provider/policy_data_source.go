@@ -4,14 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
 )
 
-var _ datasource.DataSource = (*policyDataSource)(nil)
+var (
+	_ datasource.DataSource                     = (*policyDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*policyDataSource)(nil)
+)
 
 func NewPolicyDataSource() datasource.DataSource {
 	return &policyDataSource{}
@@ -40,11 +47,13 @@ func (d *policyDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 		Description: "Fetches an existing Policy from the Control Plane.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Policy ID to lookup",
-				Required:    true,
+				Description: "Policy ID to lookup. Exactly one of id or name must be set",
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "Policy name",
+				Description: "Policy name to lookup. Exactly one of id or name must be set",
+				Optional:    true,
 				Computed:    true,
 			},
 			"description": schema.StringAttribute{
@@ -71,6 +80,15 @@ func (d *policyDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 	}
 }
 
+func (d *policyDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
 func (d *policyDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -96,36 +114,58 @@ func (d *policyDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	policy, err := d.client.GetPolicy(config.ID.ValueString())
+	id := config.ID.ValueString()
+	if config.ID.IsNull() {
+		var err error
+		id, err = lookupPolicyID(ctx, d.client, config.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading policy", err.Error())
+			return
+		}
+	}
+
+	policy, err := d.client.GetPolicy(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading policy", err.Error())
 		return
 	}
 
-	// Map response to state
-	config.Name = types.StringValue(policy.Name)
+	resp.Diagnostics.Append(populatePolicyDataSourceModel(ctx, &config, policy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// populatePolicyDataSourceModel copies the fields of an API policy into a data source model
+func populatePolicyDataSourceModel(ctx context.Context, model *policyDataSourceModel, policy *entities.Policy) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(policy.ID)
+	model.Name = types.StringValue(policy.Name)
 
 	if policy.Description != nil {
-		config.Description = types.StringValue(*policy.Description)
+		model.Description = types.StringValue(*policy.Description)
 	} else {
-		config.Description = types.StringNull()
+		model.Description = types.StringNull()
 	}
 
-	config.Policy = types.StringValue(policy.PolicyContent)
-	config.Enabled = types.BoolValue(policy.Enabled)
+	model.Policy = types.StringValue(policy.PolicyContent)
+	model.Enabled = types.BoolValue(policy.Enabled)
 
 	if policy.CreatedAt != nil {
-		config.CreatedAt = types.StringValue(policy.CreatedAt.String())
+		model.CreatedAt = types.StringValue(policy.CreatedAt.String())
 	} else {
-		config.CreatedAt = types.StringNull()
+		model.CreatedAt = types.StringNull()
 	}
 
 	if policy.UpdatedAt != nil {
-		config.UpdatedAt = types.StringValue(policy.UpdatedAt.String())
+		model.UpdatedAt = types.StringValue(policy.UpdatedAt.String())
 	} else {
-		config.UpdatedAt = types.StringNull()
+		model.UpdatedAt = types.StringNull()
 	}
 
-	diags = resp.State.Set(ctx, &config)
-	resp.Diagnostics.Append(diags...)
+	return diags
 }
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/open-policy-agent/opa/v1/ast"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
+)
+
+var (
+	_ datasource.DataSource                     = (*policyEvaluationDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*policyEvaluationDataSource)(nil)
+)
+
+func NewPolicyEvaluationDataSource() datasource.DataSource {
+	return &policyEvaluationDataSource{}
+}
+
+type policyEvaluationDataSource struct {
+	client *clients.Client
+}
+
+type policyEvaluationDataSourceModel struct {
+	PolicyContent types.String `tfsdk:"policy_content"`
+	PolicyID      types.String `tfsdk:"policy_id"`
+	Input         types.String `tfsdk:"input"`
+	Query         types.String `tfsdk:"query"`
+	Defined       types.Bool   `tfsdk:"defined"`
+	Result        types.String `tfsdk:"result"`
+}
+
+func (d *policyEvaluationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_evaluation"
+}
+
+func (d *policyEvaluationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Evaluates a Rego policy against a sample input locally, with the OPA engine embedded in the provider. Nothing is sent to the Control Plane except, with policy_id, the request to fetch the policy.",
+		Attributes: map[string]schema.Attribute{
+			"policy_content": schema.StringAttribute{
+				Description: "Rego policy content to evaluate. Exactly one of policy_content or policy_id must be set",
+				Optional:    true,
+			},
+			"policy_id": schema.StringAttribute{
+				Description: "ID of a Rego policy in the Control Plane to evaluate. Exactly one of policy_content or policy_id must be set",
+				Optional:    true,
+			},
+			"input": schema.StringAttribute{
+				Description: "JSON document available to the policy as input. When unset, input is undefined",
+				Optional:    true,
+			},
+			"query": schema.StringAttribute{
+				Description: "Rego query to evaluate, e.g. data.kubiya.allow",
+				Required:    true,
+			},
+			"defined": schema.BoolAttribute{
+				Description: "Whether the query produced a result. A rule whose conditions do not hold and that has no default is undefined",
+				Computed:    true,
+			},
+			"result": schema.StringAttribute{
+				Description: "JSON encoded value of the query, or null when the query is undefined. A query with more than one expression returns an object of its variable bindings",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *policyEvaluationDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("policy_content"),
+			path.MatchRoot("policy_id"),
+		),
+	}
+}
+
+func (d *policyEvaluationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *policyEvaluationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config policyEvaluationDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	content := config.PolicyContent.ValueString()
+	contentPath := path.Root("policy_content")
+	if config.PolicyContent.IsNull() {
+		policy, err := d.client.GetPolicy(ctx, config.PolicyID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading policy", err.Error())
+			return
+		}
+
+		if policy.PolicyType != "" && policy.PolicyType != entities.PolicyTypeRego {
+			resp.Diagnostics.AddAttributeError(path.Root("policy_id"), "Unsupported Policy Type",
+				fmt.Sprintf("Only rego policies can be evaluated, but policy %s is of type %s", policy.ID, policy.PolicyType))
+			return
+		}
+
+		content = policy.PolicyContent
+		contentPath = path.Root("policy_id")
+	}
+
+	module, problems := parseRego(regoPolicyFile, content)
+	var compiler *ast.Compiler
+	if len(problems) == 0 {
+		compiler, problems = compileRego(map[string]*ast.Module{regoPolicyFile: module})
+	}
+	for _, problem := range problems {
+		resp.Diagnostics.AddAttributeError(contentPath, "Invalid Rego Policy", problem.String())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var input interface{}
+	if !config.Input.IsNull() {
+		decoder := json.NewDecoder(strings.NewReader(config.Input.ValueString()))
+		decoder.UseNumber()
+		err := decoder.Decode(&input)
+		if err == nil && decoder.More() {
+			err = fmt.Errorf("unexpected data after the JSON document")
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("input"), "Invalid Input", fmt.Sprintf("input must be a JSON document: %s", err))
+			return
+		}
+	}
+
+	value, defined, err := evaluateRego(ctx, compiler, config.Query.ValueString(), input)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("query"), "Error evaluating policy", err.Error())
+		return
+	}
+
+	config.Defined = types.BoolValue(defined)
+	config.Result = types.StringNull()
+	if defined {
+		result, err := json.Marshal(value)
+		if err != nil {
+			resp.Diagnostics.AddError("Error evaluating policy", fmt.Sprintf("failed to encode result: %s", err))
+			return
+		}
+		config.Result = types.StringValue(string(result))
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
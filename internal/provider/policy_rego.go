@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/rego"
+	"github.com/open-policy-agent/opa/v1/tester"
+)
+
+// regoPolicyFile is the file name OPA reports in locations; diagnostics only use the line and column
+const regoPolicyFile = "policy.rego"
+
+// policyProblem is a syntax or compile error at a position in the policy content. File is set for
+// problems found while compiling several modules together.
+type policyProblem struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p policyProblem) String() string {
+	if p.Line == 0 {
+		return p.Message
+	}
+
+	// OPA reports errors at the end of the input without a column
+	if p.Column == 0 {
+		return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+	}
+
+	return fmt.Sprintf("line %d, column %d: %s", p.Line, p.Column, p.Message)
+}
+
+// parseRego parses a Rego module. Policies written before Rego v1 are still accepted: the module is
+// parsed as Rego v1 first and as Rego v0 when that fails. When neither parses, the errors of the
+// attempt that got furthest are returned, since they are the ones that point at the actual mistake.
+func parseRego(filename, content string) (*ast.Module, []policyProblem) {
+	module, errV1 := ast.ParseModuleWithOpts(filename, content, ast.ParserOptions{RegoVersion: ast.RegoV1})
+	if errV1 == nil {
+		return module, nil
+	}
+
+	module, errV0 := ast.ParseModuleWithOpts(filename, content, ast.ParserOptions{RegoVersion: ast.RegoV0})
+	if errV0 == nil {
+		return module, nil
+	}
+
+	problemsV1, problemsV0 := regoProblems(errV1), regoProblems(errV0)
+	if len(problemsV1) > 0 && len(problemsV0) > 0 && furthest(problemsV0[0], problemsV1[0]) {
+		return nil, problemsV0
+	}
+
+	return nil, problemsV1
+}
+
+// compileRego compiles parsed modules, which also type checks them against OPA's built-in functions
+func compileRego(modules map[string]*ast.Module) (*ast.Compiler, []policyProblem) {
+	compiler := ast.NewCompiler()
+	compiler.Compile(modules)
+	if compiler.Failed() {
+		return nil, regoProblems(compiler.Errors)
+	}
+
+	return compiler, nil
+}
+
+// checkRegoPolicy parses and compiles Rego policy content
+func checkRegoPolicy(content string) []policyProblem {
+	module, problems := parseRego(regoPolicyFile, content)
+	if len(problems) > 0 {
+		return problems
+	}
+
+	_, problems = compileRego(map[string]*ast.Module{regoPolicyFile: module})
+	return problems
+}
+
+// evaluateRego evaluates a query against compiled policies and input, which may be nil. It returns
+// the value of the query and whether it was defined. A query with a single expression, such as
+// data.kubiya.allow, yields the value of that expression; any other query yields its variable
+// bindings. Built-in function errors fail the evaluation instead of leaving the query undefined.
+func evaluateRego(ctx context.Context, compiler *ast.Compiler, query string, input interface{}) (interface{}, bool, error) {
+	options := []func(*rego.Rego){
+		rego.Compiler(compiler),
+		rego.Query(query),
+		rego.StrictBuiltinErrors(true),
+	}
+	if input != nil {
+		options = append(options, rego.Input(input))
+	}
+
+	results, err := rego.New(options...).Eval(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(results) == 0 {
+		return nil, false, nil
+	}
+
+	if len(results[0].Expressions) == 1 {
+		return results[0].Expressions[0].Value, true, nil
+	}
+
+	return results[0].Bindings, true, nil
+}
+
+// checkJSONPolicy checks that JSON policy content is a single well-formed JSON document
+func checkJSONPolicy(content string) []policyProblem {
+	var document interface{}
+	err := json.Unmarshal([]byte(content), &document)
+	if err == nil {
+		return nil
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line, column := lineAndColumn(content, syntaxErr.Offset)
+		return []policyProblem{{Line: line, Column: column, Message: syntaxErr.Error()}}
+	}
+
+	return []policyProblem{{Message: err.Error()}}
+}
+
+// validatePolicyContent checks policy content of the given type and reports every problem at the
+// attribute path. Types other than rego and json are left to the API.
+func validatePolicyContent(attribute path.Path, policyType, content string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var problems []policyProblem
+	var summary string
+	switch policyType {
+	case "", "rego":
+		problems, summary = checkRegoPolicy(content), "Invalid Rego Policy"
+	case "json":
+		problems, summary = checkJSONPolicy(content), "Invalid JSON Policy"
+	}
+
+	for _, problem := range problems {
+		diags.AddAttributeError(attribute, summary, problem.String())
+	}
+
+	return diags
+}
+
+// validatePolicyTests runs Rego test modules against the policy with OPA's test runner. Problems in
+// the policy are reported at contentPath and problems in a test module, including its failing
+// tests, at its index in testsPath.
+func validatePolicyTests(ctx context.Context, contentPath, testsPath path.Path, content string, tests []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	paths := map[string]path.Path{regoPolicyFile: contentPath}
+	modules := map[string]*ast.Module{}
+
+	policy, problems := parseRego(regoPolicyFile, content)
+	for _, problem := range problems {
+		diags.AddAttributeError(contentPath, "Invalid Rego Policy", problem.String())
+	}
+	modules[regoPolicyFile] = policy
+
+	for i, test := range tests {
+		filename := fmt.Sprintf("test_%d.rego", i)
+		paths[filename] = testsPath.AtListIndex(i)
+
+		module, problems := parseRego(filename, test)
+		for _, problem := range problems {
+			diags.AddAttributeError(paths[filename], "Invalid Rego Test", problem.String())
+		}
+		modules[filename] = module
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	results, err := tester.NewRunner().RaiseBuiltinErrors(true).Run(ctx, modules)
+	if err != nil {
+		for _, problem := range regoProblems(err) {
+			attribute, summary := testsPath, "Invalid Rego Test"
+			if p, ok := paths[problem.File]; ok {
+				attribute = p
+			}
+			if problem.File == regoPolicyFile {
+				summary = "Invalid Rego Policy"
+			}
+			diags.AddAttributeError(attribute, summary, problem.String())
+		}
+		return diags
+	}
+
+	ran := 0
+	for result := range results {
+		if result.Skip {
+			continue
+		}
+		ran++
+
+		attribute := testsPath
+		if result.Location != nil {
+			if p, ok := paths[result.Location.File]; ok {
+				attribute = p
+			}
+		}
+
+		name := strings.TrimPrefix(result.Package, "data.") + "." + result.Name
+		switch {
+		case result.Error != nil:
+			diags.AddAttributeError(attribute, "Rego Test Error", fmt.Sprintf("%s: %s", name, result.Error))
+		case result.Fail:
+			detail := name + " failed"
+			if result.FailedAt != nil && result.FailedAt.Location != nil {
+				detail += fmt.Sprintf(" at line %d: %s", result.FailedAt.Location.Row, result.FailedAt.Location.Text)
+			}
+			diags.AddAttributeError(attribute, "Failing Rego Test", detail)
+		}
+	}
+
+	if ran == 0 && len(tests) > 0 {
+		diags.AddAttributeWarning(testsPath, "No Rego Tests Found", "tests contain no rules named test_*, so nothing was run")
+	}
+
+	return diags
+}
+
+// regoProblems converts the errors OPA returns from parsing and compiling
+func regoProblems(err error) []policyProblem {
+	var astErrs ast.Errors
+	if !errors.As(err, &astErrs) {
+		return []policyProblem{{Message: err.Error()}}
+	}
+
+	problems := make([]policyProblem, 0, len(astErrs))
+	for _, astErr := range astErrs {
+		problem := policyProblem{Message: astErr.Message}
+		if astErr.Location != nil {
+			problem.File = astErr.Location.File
+			problem.Line, problem.Column = astErr.Location.Row, astErr.Location.Col
+		}
+		problems = append(problems, problem)
+	}
+
+	return problems
+}
+
+// furthest reports whether problem a is located after problem b
+func furthest(a, b policyProblem) bool {
+	return a.Line > b.Line || (a.Line == b.Line && a.Column > b.Column)
+}
+
+// lineAndColumn converts the offset of a json.SyntaxError, which points just past the offending byte,
+// to the 1-based line and column of that byte
+func lineAndColumn(content string, offset int64) (int, int) {
+	position := min(max(int(offset)-1, 0), len(content))
+	prefix := []byte(content[:position])
+	line := bytes.Count(prefix, []byte("\n")) + 1
+	column := position - bytes.LastIndexByte(prefix, '\n')
+
+	return line, column
+}
@@ -17,6 +17,8 @@ import (
 
 var _ resource.Resource = (*policyResource)(nil)
 var _ resource.ResourceWithImportState = (*policyResource)(nil)
+var _ resource.ResourceWithValidateConfig = (*policyResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*policyResource)(nil)
 
 func NewPolicyResource() resource.Resource {
 	return &policyResource{}
@@ -34,7 +36,9 @@ type policyResourceModel struct {
 	PolicyType    types.String `tfsdk:"policy_type"`
 	Enabled       types.Bool   `tfsdk:"enabled"`
 	Tags          types.List   `tfsdk:"tags"`
+	Tests         types.List   `tfsdk:"tests"`
 	Version       types.Int64  `tfsdk:"version"`
+	PinnedVersion types.Int64  `tfsdk:"pinned_version"`
 	CreatedAt     types.String `tfsdk:"created_at"`
 	UpdatedAt     types.String `tfsdk:"updated_at"`
 }
@@ -63,7 +67,7 @@ func (r *policyResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 			},
 			"policy_content": schema.StringAttribute{
-				Description: "OPA Rego policy content",
+				Description: "OPA Rego policy content. Rego is parsed and compiled, and JSON is checked for syntax errors, at plan time.",
 				Required:    true,
 			},
 			"policy_type": schema.StringAttribute{
@@ -80,10 +84,19 @@ func (r *policyResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"tests": schema.ListAttribute{
+				Description: "Rego test modules (the contents of _test.rego files) run against policy_content at plan time. A failing test fails the plan. Tests are not sent to the Control Plane.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 			"version": schema.Int64Attribute{
 				Description: "Policy version",
 				Computed:    true,
 			},
+			"pinned_version": schema.Int64Attribute{
+				Description: "Version whose content to restore. While set, the content of that version is sent to the Control Plane instead of policy_content, which is still validated. The rollback itself is recorded as a new version. Can only be set on an existing policy; see the controlplane_policy_versions data source for its history.",
+				Optional:    true,
+			},
 			"created_at": schema.StringAttribute{
 				Description: "Timestamp when the policy was created",
 				Computed:    true,
@@ -96,6 +109,73 @@ func (r *policyResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 	}
 }
 
+// ValidateConfig parses and compiles policy_content locally and runs its tests, so that syntax errors,
+// type errors and failing tests fail the plan instead of the apply
+func (r *policyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config policyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.PinnedVersion.IsNull() && !config.PinnedVersion.IsUnknown() && config.PinnedVersion.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("pinned_version"), "Invalid Pinned Version",
+			fmt.Sprintf("pinned_version must be at least 1, got %d", config.PinnedVersion.ValueInt64()))
+	}
+
+	if config.PolicyContent.IsNull() || config.PolicyContent.IsUnknown() || config.PolicyType.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(validatePolicyContent(path.Root("policy_content"), config.PolicyType.ValueString(), config.PolicyContent.ValueString())...)
+
+	if resp.Diagnostics.HasError() || config.Tests.IsNull() || config.Tests.IsUnknown() {
+		return
+	}
+
+	if policyType := config.PolicyType.ValueString(); policyType != "" && policyType != string(entities.PolicyTypeRego) {
+		resp.Diagnostics.AddAttributeError(path.Root("tests"), "Invalid Attribute Combination",
+			fmt.Sprintf("tests can only be run against rego policies, but policy_type is %q", policyType))
+		return
+	}
+
+	var tests []types.String
+	resp.Diagnostics.Append(config.Tests.ElementsAs(ctx, &tests, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contents := make([]string, 0, len(tests))
+	for _, test := range tests {
+		// Tests that are only known after apply cannot be run during plan
+		if test.IsUnknown() {
+			return
+		}
+		contents = append(contents, test.ValueString())
+	}
+
+	resp.Diagnostics.Append(validatePolicyTests(ctx, path.Root("policy_content"), path.Root("tests"), config.PolicyContent.ValueString(), contents)...)
+}
+
+// ModifyPlan rejects pinned_version on a policy that does not exist yet, since it has no versions to
+// restore
+func (r *policyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var pinnedVersion types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("pinned_version"), &pinnedVersion)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !pinnedVersion.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("pinned_version"), "Invalid Pinned Version",
+			"pinned_version can only be set on an existing policy. Create the policy without it, then set it to roll back.")
+	}
+}
+
 func (r *policyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -147,7 +227,7 @@ func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest,
 		createReq.Tags = tags
 	}
 
-	policy, err := r.client.CreatePolicy(createReq)
+	policy, err := r.client.CreatePolicy(ctx, createReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating policy", err.Error())
 		return
@@ -184,7 +264,7 @@ func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	policy, err := r.client.GetPolicy(state.ID.ValueString())
+	policy, err := r.client.GetPolicy(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading policy", err.Error())
 		return
@@ -192,7 +272,21 @@ func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	state.ID = types.StringValue(policy.ID)
 	state.Name = types.StringValue(policy.Name)
-	state.PolicyContent = types.StringValue(policy.PolicyContent)
+
+	// A pinned policy keeps the configured policy_content as long as the Control Plane still serves
+	// the pinned version, so that only changes made outside Terraform show up as drift
+	content := policy.PolicyContent
+	if !state.PinnedVersion.IsNull() && content != state.PolicyContent.ValueString() {
+		pinned, err := r.client.GetPolicyVersion(ctx, policy.ID, state.PinnedVersion.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading pinned policy version", err.Error())
+			return
+		}
+		if pinned.PolicyContent == content {
+			content = state.PolicyContent.ValueString()
+		}
+	}
+	state.PolicyContent = types.StringValue(content)
 	state.PolicyType = types.StringValue(string(policy.PolicyType))
 	state.Enabled = types.BoolValue(policy.Enabled)
 	state.Version = types.Int64Value(policy.Version)
@@ -227,6 +321,14 @@ func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest,
 	updateReq.Name = &name
 
 	content := plan.PolicyContent.ValueString()
+	if !plan.PinnedVersion.IsNull() {
+		pinned, err := r.client.GetPolicyVersion(ctx, plan.ID.ValueString(), plan.PinnedVersion.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("pinned_version"), "Error reading pinned policy version", err.Error())
+			return
+		}
+		content = pinned.PolicyContent
+	}
 	updateReq.PolicyContent = &content
 
 	enabled := plan.Enabled.ValueBool()
@@ -247,16 +349,23 @@ func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		updateReq.Tags = tags
 	}
 
-	policy, err := r.client.UpdatePolicy(plan.ID.ValueString(), updateReq)
+	resp.Diagnostics.Append(checkNotModifiedSinceRefresh(ctx, r.client, req.State, "policy", (*clients.Client).GetPolicy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, err := r.client.UpdatePolicy(ctx, plan.ID.ValueString(), updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating policy", err.Error())
 		return
 	}
 
-	// Update all computed fields from response
+	// Update all computed fields from response. A pinned policy keeps the configured policy_content.
 	plan.ID = types.StringValue(policy.ID)
 	plan.Name = types.StringValue(policy.Name)
-	plan.PolicyContent = types.StringValue(policy.PolicyContent)
+	if plan.PinnedVersion.IsNull() {
+		plan.PolicyContent = types.StringValue(policy.PolicyContent)
+	}
 	plan.PolicyType = types.StringValue(string(policy.PolicyType))
 	plan.Enabled = types.BoolValue(policy.Enabled)
 	plan.Version = types.Int64Value(policy.Version)
@@ -287,13 +396,16 @@ func (r *policyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.DeletePolicy(state.ID.ValueString())
+	err := r.client.DeletePolicy(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting policy", err.Error())
 		return
 	}
 }
 
+// ImportState accepts either the policy ID or the policy name
 func (r *policyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByLookup(ctx, req, resp, "policy", func(name string) (string, error) {
+		return lookupPolicyID(ctx, r.client, name)
+	})
 }
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+)
+
+var _ datasource.DataSource = (*policyVersionsDataSource)(nil)
+
+func NewPolicyVersionsDataSource() datasource.DataSource {
+	return &policyVersionsDataSource{}
+}
+
+type policyVersionsDataSource struct {
+	client *clients.Client
+}
+
+type policyVersionsDataSourceModel struct {
+	PolicyID types.String         `tfsdk:"policy_id"`
+	Versions []policyVersionModel `tfsdk:"versions"`
+}
+
+type policyVersionModel struct {
+	Version       types.Int64  `tfsdk:"version"`
+	PolicyContent types.String `tfsdk:"policy_content"`
+	Author        types.String `tfsdk:"author"`
+	CreatedAt     types.String `tfsdk:"created_at"`
+}
+
+func (d *policyVersionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_versions"
+}
+
+func (d *policyVersionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the version history of a Policy. The Control Plane records a version every time the policy content changes.",
+		Attributes: map[string]schema.Attribute{
+			"policy_id": schema.StringAttribute{
+				Description: "ID of the policy",
+				Required:    true,
+			},
+			"versions": schema.ListNestedAttribute{
+				Description: "Versions of the policy, oldest first",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"version": schema.Int64Attribute{
+							Description: "Version number",
+							Computed:    true,
+						},
+						"policy_content": schema.StringAttribute{
+							Description: "Policy content of this version",
+							Computed:    true,
+						},
+						"author": schema.StringAttribute{
+							Description: "User who created this version",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "Timestamp when this version was created",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *policyVersionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *policyVersionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data policyVersionsDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	versions, err := d.client.ListPolicyVersions(ctx, data.PolicyID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing policy versions", err.Error())
+		return
+	}
+
+	data.Versions = make([]policyVersionModel, 0, len(versions))
+	for _, version := range versions {
+		model := policyVersionModel{
+			Version:       types.Int64Value(version.Version),
+			PolicyContent: types.StringValue(version.PolicyContent),
+			Author:        types.StringPointerValue(version.CreatedBy),
+			CreatedAt:     types.StringNull(),
+		}
+
+		if version.CreatedAt != nil {
+			model.CreatedAt = types.StringValue(version.CreatedAt.String())
+		}
+
+		data.Versions = append(data.Versions, model)
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
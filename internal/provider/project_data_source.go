@@ -4,14 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
 )
 
-var _ datasource.DataSource = (*projectDataSource)(nil)
+var (
+	_ datasource.DataSource                     = (*projectDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*projectDataSource)(nil)
+)
 
 func NewProjectDataSource() datasource.DataSource {
 	return &projectDataSource{}
@@ -48,15 +55,18 @@ func (d *projectDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 		Description: "Fetches an existing Project from the Control Plane.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Project ID to lookup",
-				Required:    true,
+				Description: "Project ID to lookup. Exactly one of id, name or key must be set",
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "Project name",
+				Description: "Project name to lookup. Exactly one of id, name or key must be set",
+				Optional:    true,
 				Computed:    true,
 			},
 			"key": schema.StringAttribute{
-				Description: "Short project key",
+				Description: "Short project key to lookup. Exactly one of id, name or key must be set",
+				Optional:    true,
 				Computed:    true,
 			},
 			"description": schema.StringAttribute{
@@ -112,6 +122,16 @@ func (d *projectDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 	}
 }
 
+func (d *projectDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+			path.MatchRoot("key"),
+		),
+	}
+}
+
 func (d *projectDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -137,43 +157,70 @@ func (d *projectDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	project, err := d.client.GetProject(config.ID.ValueString())
+	id := config.ID.ValueString()
+	if config.ID.IsNull() {
+		var err error
+		if !config.Key.IsNull() {
+			id, err = lookupProjectIDByKey(ctx, d.client, config.Key.ValueString())
+		} else {
+			id, err = lookupProjectIDByName(ctx, d.client, config.Name.ValueString())
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading project", err.Error())
+			return
+		}
+	}
+
+	project, err := d.client.GetProject(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading project", err.Error())
 		return
 	}
 
-	// Map response to state
-	config.Name = types.StringValue(project.Name)
-	config.Key = types.StringValue(project.Key)
+	resp.Diagnostics.Append(populateProjectDataSourceModel(ctx, &config, project)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// populateProjectDataSourceModel copies the fields of an API project into a data source model
+func populateProjectDataSourceModel(ctx context.Context, model *projectDataSourceModel, project *entities.Project) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(project.ID)
+	model.Name = types.StringValue(project.Name)
+	model.Key = types.StringValue(project.Key)
 
 	if project.Description != nil {
-		config.Description = types.StringValue(*project.Description)
+		model.Description = types.StringValue(*project.Description)
 	} else {
-		config.Description = types.StringNull()
+		model.Description = types.StringNull()
 	}
 
 	if project.Goals != nil {
-		config.Goals = types.StringValue(*project.Goals)
+		model.Goals = types.StringValue(*project.Goals)
 	} else {
-		config.Goals = types.StringNull()
+		model.Goals = types.StringNull()
 	}
 
 	// Convert settings to JSON string
 	if len(project.Settings) > 0 {
 		settingsJSON, err := toJSONString(project.Settings)
 		if err != nil {
-			resp.Diagnostics.AddError("Error converting settings", err.Error())
-			return
+			diags.AddError("Error converting settings", err.Error())
+			return diags
 		}
-		config.Settings = types.StringValue(settingsJSON)
+		model.Settings = types.StringValue(settingsJSON)
 	} else {
-		config.Settings = types.StringNull()
+		model.Settings = types.StringNull()
 	}
 
-	config.Status = types.StringValue(string(project.Status))
-	config.Visibility = types.StringValue(project.Visibility)
-	config.RestrictToEnvironment = types.BoolValue(project.RestrictToEnvironment)
+	model.Status = types.StringValue(string(project.Status))
+	model.Visibility = types.StringValue(project.Visibility)
+	model.RestrictToEnvironment = types.BoolValue(project.RestrictToEnvironment)
 
 	// Convert policy IDs to list
 	if len(project.PolicyIDs) > 0 {
@@ -181,34 +228,33 @@ func (d *projectDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		for i, id := range project.PolicyIDs {
 			policyList[i] = types.StringValue(id)
 		}
-		listVal, diags := types.ListValueFrom(ctx, types.StringType, policyList)
-		resp.Diagnostics.Append(diags...)
-		config.PolicyIDs = listVal
+		listVal, listDiags := types.ListValueFrom(ctx, types.StringType, policyList)
+		diags.Append(listDiags...)
+		model.PolicyIDs = listVal
 	} else {
-		config.PolicyIDs = types.ListNull(types.StringType)
+		model.PolicyIDs = types.ListNull(types.StringType)
 	}
 
 	if project.DefaultModel != nil {
-		config.DefaultModel = types.StringValue(*project.DefaultModel)
+		model.DefaultModel = types.StringValue(*project.DefaultModel)
 	} else {
-		config.DefaultModel = types.StringNull()
+		model.DefaultModel = types.StringNull()
 	}
 
-	config.AgentCount = types.Int64Value(int64(project.AgentCount))
-	config.TeamCount = types.Int64Value(int64(project.TeamCount))
+	model.AgentCount = types.Int64Value(int64(project.AgentCount))
+	model.TeamCount = types.Int64Value(int64(project.TeamCount))
 
 	if project.CreatedAt != nil {
-		config.CreatedAt = types.StringValue(project.CreatedAt.String())
+		model.CreatedAt = types.StringValue(project.CreatedAt.String())
 	} else {
-		config.CreatedAt = types.StringNull()
+		model.CreatedAt = types.StringNull()
 	}
 
 	if project.UpdatedAt != nil {
-		config.UpdatedAt = types.StringValue(project.UpdatedAt.String())
+		model.UpdatedAt = types.StringValue(project.UpdatedAt.String())
 	} else {
-		config.UpdatedAt = types.StringNull()
+		model.UpdatedAt = types.StringNull()
 	}
 
-	diags = resp.State.Set(ctx, &config)
-	resp.Diagnostics.Append(diags...)
+	return diags
 }
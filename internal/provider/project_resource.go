@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -186,7 +185,7 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	// Create project
-	project, err := r.client.CreateProject(createReq)
+	project, err := r.client.CreateProject(ctx, createReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating project", err.Error())
 		return
@@ -233,7 +232,7 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	project, err := r.client.GetProject(state.ID.ValueString())
+	project, err := r.client.GetProject(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading project", err.Error())
 		return
@@ -329,8 +328,13 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 		updateReq.DefaultModel = &model
 	}
 
+	resp.Diagnostics.Append(checkNotModifiedSinceRefresh(ctx, r.client, req.State, "project", (*clients.Client).GetProject)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Update project
-	project, err := r.client.UpdateProject(state.ID.ValueString(), updateReq)
+	project, err := r.client.UpdateProject(ctx, state.ID.ValueString(), updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating project", err.Error())
 		return
@@ -382,13 +386,16 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	err := r.client.DeleteProject(state.ID.ValueString())
+	err := r.client.DeleteProject(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting project", err.Error())
 		return
 	}
 }
 
+// ImportState accepts either the project ID or the project key
 func (r *projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByLookup(ctx, req, resp, "project", func(key string) (string, error) {
+		return lookupProjectIDByKey(ctx, r.client, key)
+	})
 }
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+)
+
+var _ datasource.DataSource = (*projectsDataSource)(nil)
+
+func NewProjectsDataSource() datasource.DataSource {
+	return &projectsDataSource{}
+}
+
+type projectsDataSource struct {
+	client *clients.Client
+}
+
+type projectsDataSourceModel struct {
+	NameRegex  types.String             `tfsdk:"name_regex"`
+	Status     types.String             `tfsdk:"status"`
+	Visibility types.String             `tfsdk:"visibility"`
+	Projects   []projectDataSourceModel `tfsdk:"projects"`
+}
+
+func (d *projectsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_projects"
+}
+
+func (d *projectsDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches Projects from the Control Plane, optionally filtered.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": nameRegexAttribute("projects"),
+			"status": schema.StringAttribute{
+				Description: "Only return projects with this status (active, archived, paused)",
+				Optional:    true,
+			},
+			"visibility": schema.StringAttribute{
+				Description: "Only return projects with this visibility (private or org)",
+				Optional:    true,
+			},
+			"projects": schema.ListNestedAttribute{
+				Description: "List of projects matching the filters",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: nestedDataSourceAttributes(ctx, NewProjectDataSource()),
+				},
+			},
+		},
+	}
+}
+
+func (d *projectsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *projectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data projectsDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := compileNameRegex(data.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid name_regex", err.Error())
+		return
+	}
+
+	opts := &clients.ListOptions{Filters: map[string]string{
+		"status":     data.Status.ValueString(),
+		"visibility": data.Visibility.ValueString(),
+	}}
+
+	data.Projects = []projectDataSourceModel{}
+	for project, err := range d.client.IterProjects(ctx, opts) {
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing projects", err.Error())
+			return
+		}
+
+		if !matchesNameRegex(nameRegex, project.Name) ||
+			!matchesString(data.Status, string(project.Status)) ||
+			!matchesString(data.Visibility, project.Visibility) {
+			continue
+		}
+
+		var projectModel projectDataSourceModel
+		resp.Diagnostics.Append(populateProjectDataSourceModel(ctx, &projectModel, project)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Projects = append(data.Projects, projectModel)
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
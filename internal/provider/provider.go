@@ -2,13 +2,18 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	"github.com/getsentry/sentry-go"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-kubiya-control-plane/internal/clients"
 	kubiyasentry "terraform-provider-kubiya-control-plane/internal/sentry"
@@ -18,6 +23,15 @@ type kubiyaControlPlaneProvider struct {
 	version string
 }
 
+type kubiyaControlPlaneProviderModel struct {
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	Burst                 types.Int64   `tfsdk:"burst"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+	SkipConflictCheck     types.Bool    `tfsdk:"skip_conflict_check"`
+	CacheReads            types.Bool    `tfsdk:"cache_reads"`
+	PageSize              types.Int64   `tfsdk:"page_size"`
+}
+
 var _ provider.Provider = (*kubiyaControlPlaneProvider)(nil)
 
 func New(version string) func() provider.Provider {
@@ -30,11 +44,15 @@ func (p *kubiyaControlPlaneProvider) Resources(context.Context) []func() resourc
 	return []func() resource.Resource{
 		NewAgentResource,
 		NewTeamResource,
+		NewTeamMemberResource,
+		NewTeamMembersResource,
 		NewProjectResource,
 		NewEnvironmentResource,
 		NewSkillResource,
 		NewWorkerQueueResource,
 		NewPolicyResource,
+		NewPolicyBundleResource,
+		NewPolicyAttachmentResource,
 		NewJobResource,
 	}
 }
@@ -42,11 +60,20 @@ func (p *kubiyaControlPlaneProvider) Resources(context.Context) []func() resourc
 func (p *kubiyaControlPlaneProvider) DataSources(context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewAgentDataSource,
+		NewAgentsDataSource,
 		NewTeamDataSource,
+		NewTeamsDataSource,
 		NewProjectDataSource,
+		NewProjectsDataSource,
 		NewEnvironmentDataSource,
+		NewEnvironmentsDataSource,
 		NewSkillDataSource,
+		NewSkillsDataSource,
+		NewSkillDefinitionsDataSource,
 		NewPolicyDataSource,
+		NewPoliciesDataSource,
+		NewPolicyEvaluationDataSource,
+		NewPolicyVersionsDataSource,
 		NewWorkerQueueDataSource,
 		NewWorkerQueuesDataSource,
 		NewJobDataSource,
@@ -55,14 +82,48 @@ func (p *kubiyaControlPlaneProvider) DataSources(context.Context) []func() datas
 }
 
 func (p *kubiyaControlPlaneProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
-	resp.Schema = schema.Schema{}
+	resp.Schema = schema.Schema{
+		Description: "The API key is read from the KUBIYA_CONTROL_PLANE_API_KEY environment variable.",
+		Attributes: map[string]schema.Attribute{
+			"requests_per_second": schema.Float64Attribute{
+				Description: "Maximum sustained rate of API requests per second. Unset or 0 disables client-side rate limiting; " +
+					"the provider still slows down when the API reports its rate limit is exhausted",
+				Optional: true,
+			},
+			"burst": schema.Int64Attribute{
+				Description: "Number of requests that may be sent at once before requests_per_second applies. Default: requests_per_second rounded up",
+				Optional:    true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of API requests in flight at the same time. Unset or 0 means no cap",
+				Optional:    true,
+			},
+			"skip_conflict_check": schema.BoolAttribute{
+				Description: "Update objects even if they were modified outside Terraform since the last refresh. Default: false",
+				Optional:    true,
+			},
+			"cache_reads": schema.BoolAttribute{
+				Description: "Cache GET responses in memory for the lifetime of the provider process and collapse concurrent identical GETs. " +
+					"Every write empties the cache, since it can also change objects other than the one it targets. Default: false",
+				Optional: true,
+			},
+			"page_size": schema.Int64Attribute{
+				Description: fmt.Sprintf("Number of items requested per page when listing objects, between 1 and %d. "+
+					"Takes precedence over the KUBIYA_CONTROL_PLANE_PAGE_SIZE environment variable. Default: %d", clients.MaxPageSize, clients.DefaultPageSize),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, clients.MaxPageSize),
+				},
+			},
+		},
+	}
 }
 
 func (p *kubiyaControlPlaneProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "controlplane"
 }
 
-func (p *kubiyaControlPlaneProvider) Configure(ctx context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+func (p *kubiyaControlPlaneProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	// Initialize Sentry when provider is configured
 	// Sentry is optional for functionality, so we ignore initialization errors
 	_ = kubiyasentry.Initialize()
@@ -96,6 +157,33 @@ func (p *kubiyaControlPlaneProvider) Configure(ctx context.Context, _ provider.C
 		return
 	}
 
+	var config kubiyaControlPlaneProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		kubiyasentry.SetSpanStatus(span, sentry.SpanStatusInvalidArgument)
+		return
+	}
+
+	rateLimits := clients.RateLimitConfig{
+		RequestsPerSecond:     config.RequestsPerSecond.ValueFloat64(),
+		Burst:                 int(config.Burst.ValueInt64()),
+		MaxConcurrentRequests: int(config.MaxConcurrentRequests.ValueInt64()),
+	}
+
+	if rateLimits.RequestsPerSecond < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("requests_per_second"), "Invalid Rate Limit", "requests_per_second must not be negative.")
+	}
+	if rateLimits.Burst < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("burst"), "Invalid Rate Limit", "burst must not be negative.")
+	}
+	if rateLimits.MaxConcurrentRequests < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_concurrent_requests"), "Invalid Rate Limit", "max_concurrent_requests must not be negative.")
+	}
+	if resp.Diagnostics.HasError() {
+		kubiyasentry.SetSpanStatus(span, sentry.SpanStatusInvalidArgument)
+		return
+	}
+
 	// Set Sentry tags
 	sentry.ConfigureScope(func(scope *sentry.Scope) {
 		scope.SetTag("provider.version", p.version)
@@ -114,6 +202,23 @@ func (p *kubiyaControlPlaneProvider) Configure(ctx context.Context, _ provider.C
 		return
 	}
 
+	client.SetRateLimits(rateLimits)
+	client.SkipConflictCheck = config.SkipConflictCheck.ValueBool()
+	if !config.PageSize.IsNull() {
+		client.PageSize = int(config.PageSize.ValueInt64())
+	}
+	if config.CacheReads.ValueBool() {
+		client.EnableReadCache()
+	}
+	client.UserAgent = fmt.Sprintf("terraform-provider-kubiya-control-plane/%s terraform/%s", p.version, req.TerraformVersion)
+	logger.Debug("Configured client rate limits",
+		"requests_per_second", rateLimits.RequestsPerSecond,
+		"burst", rateLimits.Burst,
+		"max_concurrent_requests", rateLimits.MaxConcurrentRequests,
+		"cache_reads", config.CacheReads.ValueBool(),
+		"page_size", client.PageSize,
+	)
+
 	// Success
 	logger.Info("Successfully configured Kubiya Control Plane provider", "version", p.version)
 	kubiyasentry.SetSpanStatus(span, sentry.SpanStatusOK)
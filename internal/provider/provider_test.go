@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+)
+
+// configureProvider configures the provider with the given attributes; the others are null
+func configureProvider(t *testing.T, attributes map[string]tftypes.Value) *provider.ConfigureResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	p := New("test")()
+	var schema provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schema)
+	require.False(t, schema.Diagnostics.HasError())
+
+	objectType := schema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range attributes {
+		values[name] = value
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schema.Schema, Raw: tftypes.NewValue(objectType, values)},
+	}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	return resp
+}
+
+func TestConfigurePageSize(t *testing.T) {
+	t.Setenv("KUBIYA_CONTROL_PLANE_API_KEY", "test-api-key")
+	t.Setenv("KUBIYA_CONTROL_PLANE_PAGE_SIZE", "50")
+
+	resp := configureProvider(t, nil)
+	assert.Equal(t, 50, resp.ResourceData.(*clients.Client).PageSize, "the environment variable applies when page_size is unset")
+
+	resp = configureProvider(t, map[string]tftypes.Value{"page_size": tftypes.NewValue(tftypes.Number, 5)})
+	assert.Equal(t, 5, resp.ResourceData.(*clients.Client).PageSize, "page_size takes precedence over the environment variable")
+}
@@ -0,0 +1,294 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	"terraform-provider-kubiya-control-plane/internal/entities"
+)
+
+// skillConfigurationBlocks holds the typed configuration block of each skill type that has one. The
+// block is named after the skill type, and each of its attributes is sent to the API under the same
+// key in the skill's configuration.
+var skillConfigurationBlocks = map[string]map[string]schema.Attribute{
+	"shell": {
+		"allowed_commands": schema.ListAttribute{
+			Description: "Commands the skill may run. When unset, every command that is not blocked may run",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"blocked_commands": schema.ListAttribute{
+			Description: "Commands the skill may never run",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"timeout": schema.Int64Attribute{
+			Description: "Maximum run time of a command in seconds",
+			Optional:    true,
+		},
+	},
+	"file_system": {
+		"base_dir": schema.StringAttribute{
+			Description: "Directory that relative paths are resolved against",
+			Optional:    true,
+		},
+		"read_only": schema.BoolAttribute{
+			Description: "Whether the skill may only read files",
+			Optional:    true,
+		},
+		"allowed_paths": schema.ListAttribute{
+			Description: "Paths the skill may access",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+	},
+	"docker": {
+		"allowed_images": schema.ListAttribute{
+			Description: "Images the skill may run containers from",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"network": schema.StringAttribute{
+			Description: "Docker network containers are attached to",
+			Optional:    true,
+		},
+	},
+	"python": {
+		"packages": schema.ListAttribute{
+			Description: "Packages installed for the skill",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+		"allowed_imports": schema.ListAttribute{
+			Description: "Modules scripts may import",
+			Optional:    true,
+			ElementType: types.StringType,
+		},
+	},
+}
+
+// skillConfigurationSchemaBlocks returns the schema of the typed configuration blocks
+func skillConfigurationSchemaBlocks() map[string]schema.Block {
+	blocks := make(map[string]schema.Block, len(skillConfigurationBlocks))
+	for skillType, attributes := range skillConfigurationBlocks {
+		blocks[skillType] = schema.SingleNestedBlock{
+			Description: fmt.Sprintf("Typed configuration of %s skills, merged into configuration. Only allowed when type is %q", skillType, skillType),
+			Attributes:  attributes,
+		}
+	}
+
+	return blocks
+}
+
+// configurationBlocks returns the typed configuration blocks of the model keyed by skill type
+func (m *skillResourceModel) configurationBlocks() map[string]types.Object {
+	return map[string]types.Object{
+		"shell":       m.Shell,
+		"file_system": m.FileSystem,
+		"docker":      m.Docker,
+		"python":      m.Python,
+	}
+}
+
+// skillConfiguration returns the configuration to send for the model: the configuration JSON merged
+// with the block matching the skill type. It returns nil when neither is set.
+func skillConfiguration(ctx context.Context, model *skillResourceModel) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var config map[string]interface{}
+	if !model.Configuration.IsNull() {
+		var err error
+		config, err = parseJSON(model.Configuration.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("configuration"), "Invalid Configuration", fmt.Sprintf("Failed to parse configuration JSON: %s", err))
+			return nil, diags
+		}
+	}
+
+	block, ok := model.configurationBlocks()[model.Type.ValueString()]
+	if !ok || block.IsNull() {
+		return config, diags
+	}
+
+	values, d := skillBlockValues(ctx, block)
+	diags.Append(d...)
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+	maps.Copy(config, values)
+
+	return config, diags
+}
+
+// validateSkillConfigurationBlocks checks that only the block matching the skill type is set, and that
+// it does not set a key that configuration sets as well
+func validateSkillConfigurationBlocks(ctx context.Context, model *skillResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if model.Type.IsUnknown() {
+		return diags
+	}
+
+	skillType := model.Type.ValueString()
+	blocks := model.configurationBlocks()
+	for _, name := range slices.Sorted(maps.Keys(blocks)) {
+		if name != skillType && !blocks[name].IsNull() {
+			diags.AddAttributeError(path.Root(name), "Invalid Skill Configuration",
+				fmt.Sprintf("The %s block can only be set when type is %q, but type is %q", name, name, skillType))
+		}
+	}
+
+	block, ok := blocks[skillType]
+	if !ok || block.IsNull() || model.Configuration.IsNull() || model.Configuration.IsUnknown() {
+		return diags
+	}
+
+	config, err := parseJSON(model.Configuration.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("configuration"), "Invalid Configuration", fmt.Sprintf("Failed to parse configuration JSON: %s", err))
+		return diags
+	}
+
+	values, d := skillBlockValues(ctx, block)
+	diags.Append(d...)
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		if _, ok := config[key]; ok {
+			diags.AddAttributeError(path.Root(skillType).AtName(key), "Conflicting Skill Configuration",
+				fmt.Sprintf("%s is set both in the %s block and in configuration; set it in one place", key, skillType))
+		}
+	}
+
+	return diags
+}
+
+// skillBlockValues converts the attributes set in a typed configuration block to configuration
+// values. Unset and unknown attributes are left out.
+func skillBlockValues(ctx context.Context, block types.Object) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := map[string]interface{}{}
+	for key, value := range block.Attributes() {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		switch v := value.(type) {
+		case types.String:
+			values[key] = v.ValueString()
+		case types.Bool:
+			values[key] = v.ValueBool()
+		case types.Int64:
+			values[key] = v.ValueInt64()
+		case types.List:
+			// Lists with elements only known after apply are checked during apply
+			if slices.ContainsFunc(v.Elements(), attr.Value.IsUnknown) {
+				continue
+			}
+			var items []string
+			diags.Append(v.ElementsAs(ctx, &items, false)...)
+			values[key] = items
+		}
+	}
+
+	return values, diags
+}
+
+// hasUnknownValues reports whether a typed configuration block, one of its attributes or one of their
+// list elements is unknown. A block that is not set has no unknown values.
+func hasUnknownValues(block types.Object) bool {
+	if block.IsUnknown() {
+		return true
+	}
+
+	for _, value := range block.Attributes() {
+		if value.IsUnknown() {
+			return true
+		}
+		if list, ok := value.(types.List); ok && slices.ContainsFunc(list.Elements(), attr.Value.IsUnknown) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// skillSchemaProblem is a place where a skill configuration does not match the JSON Schema of its
+// skill type. Key is the top-level configuration key the problem is in, or empty for the whole object.
+type skillSchemaProblem struct {
+	Key     string
+	Message string
+}
+
+// validateSkillConfigurationSchema checks a skill configuration against the JSON Schema of its skill
+// definition. It returns an error when the schema itself cannot be compiled.
+func validateSkillConfigurationSchema(definition *entities.SkillDefinition, config map[string]interface{}) ([]skillSchemaProblem, error) {
+	if definition.ConfigurationSchema == nil {
+		return nil, nil
+	}
+
+	schemaDoc, err := decodeJSONSchemaValue(definition.ConfigurationSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaURL := "https://control-plane.kubiya.ai/skill-definitions/" + string(definition.Type) + ".json"
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(schemaURL, schemaDoc); err != nil {
+		return nil, err
+	}
+	compiled, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := decodeJSONSchemaValue(config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = compiled.Validate(instance)
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+
+	var problems []skillSchemaProblem
+	for _, unit := range validationErr.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+
+		location := strings.Split(strings.TrimPrefix(unit.InstanceLocation, "/"), "/")
+		message := unit.Error.String()
+		if location[0] != "" {
+			message = fmt.Sprintf("%s: %s", strings.Join(location, "."), message)
+		}
+		problems = append(problems, skillSchemaProblem{Key: location[0], Message: message})
+	}
+
+	return problems, nil
+}
+
+// decodeJSONSchemaValue converts a decoded JSON value to the representation the jsonschema package
+// validates, which keeps numbers exact
+func decodeJSONSchemaValue(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonschema.UnmarshalJSON(bytes.NewReader(data))
+}
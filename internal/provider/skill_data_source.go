@@ -4,14 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
 )
 
-var _ datasource.DataSource = (*skillDataSource)(nil)
+var (
+	_ datasource.DataSource                     = (*skillDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*skillDataSource)(nil)
+)
 
 func NewSkillDataSource() datasource.DataSource {
 	return &skillDataSource{}
@@ -41,11 +48,13 @@ func (d *skillDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 		Description: "Fetches an existing Skill from the Control Plane.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Skill ID to lookup",
-				Required:    true,
+				Description: "Skill ID to lookup. Exactly one of id or name must be set",
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "Skill name",
+				Description: "Skill name to lookup. Exactly one of id or name must be set",
+				Optional:    true,
 				Computed:    true,
 			},
 			"description": schema.StringAttribute{
@@ -76,6 +85,15 @@ func (d *skillDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 	}
 }
 
+func (d *skillDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
 func (d *skillDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -101,49 +119,71 @@ func (d *skillDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	skill, err := d.client.GetSkill(config.ID.ValueString())
+	id := config.ID.ValueString()
+	if config.ID.IsNull() {
+		var err error
+		id, err = lookupSkillID(ctx, d.client, config.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading skill", err.Error())
+			return
+		}
+	}
+
+	skill, err := d.client.GetSkill(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading skill", err.Error())
 		return
 	}
 
-	// Map response to state
-	config.Name = types.StringValue(skill.Name)
+	resp.Diagnostics.Append(populateSkillDataSourceModel(ctx, &config, skill)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// populateSkillDataSourceModel copies the fields of an API skill into a data source model
+func populateSkillDataSourceModel(ctx context.Context, model *skillDataSourceModel, skill *entities.Skill) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(skill.ID)
+	model.Name = types.StringValue(skill.Name)
 
 	if skill.Description != nil {
-		config.Description = types.StringValue(*skill.Description)
+		model.Description = types.StringValue(*skill.Description)
 	} else {
-		config.Description = types.StringNull()
+		model.Description = types.StringNull()
 	}
 
-	config.Type = types.StringValue(string(skill.Type))
+	model.Type = types.StringValue(string(skill.Type))
 
 	// Convert configuration to JSON string
 	if len(skill.Configuration) > 0 {
 		configJSON, err := toJSONString(skill.Configuration)
 		if err != nil {
-			resp.Diagnostics.AddError("Error converting configuration", err.Error())
-			return
+			diags.AddError("Error converting configuration", err.Error())
+			return diags
 		}
-		config.Configuration = types.StringValue(configJSON)
+		model.Configuration = types.StringValue(configJSON)
 	} else {
-		config.Configuration = types.StringNull()
+		model.Configuration = types.StringNull()
 	}
 
-	config.Enabled = types.BoolValue(skill.Enabled)
+	model.Enabled = types.BoolValue(skill.Enabled)
 
 	if skill.CreatedAt != nil {
-		config.CreatedAt = types.StringValue(skill.CreatedAt.String())
+		model.CreatedAt = types.StringValue(skill.CreatedAt.String())
 	} else {
-		config.CreatedAt = types.StringNull()
+		model.CreatedAt = types.StringNull()
 	}
 
 	if skill.UpdatedAt != nil {
-		config.UpdatedAt = types.StringValue(skill.UpdatedAt.String())
+		model.UpdatedAt = types.StringValue(skill.UpdatedAt.String())
 	} else {
-		config.UpdatedAt = types.StringNull()
+		model.UpdatedAt = types.StringNull()
 	}
 
-	diags = resp.State.Set(ctx, &config)
-	resp.Diagnostics.Append(diags...)
+	return diags
 }
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
+)
+
+var _ datasource.DataSource = (*skillDefinitionsDataSource)(nil)
+
+func NewSkillDefinitionsDataSource() datasource.DataSource {
+	return &skillDefinitionsDataSource{}
+}
+
+type skillDefinitionsDataSource struct {
+	client *clients.Client
+}
+
+type skillDefinitionsDataSourceModel struct {
+	Definitions []skillDefinitionModel `tfsdk:"definitions"`
+}
+
+type skillDefinitionModel struct {
+	Type                 types.String `tfsdk:"type"`
+	Name                 types.String `tfsdk:"name"`
+	Description          types.String `tfsdk:"description"`
+	Icon                 types.String `tfsdk:"icon"`
+	Custom               types.Bool   `tfsdk:"custom"`
+	DefaultConfiguration types.String `tfsdk:"default_configuration"`
+	ConfigurationSchema  types.String `tfsdk:"configuration_schema"`
+}
+
+func (d *skillDefinitionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_skill_definitions"
+}
+
+func (d *skillDefinitionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the catalog of skill types the Control Plane supports, including custom types registered by the organization.",
+		Attributes: map[string]schema.Attribute{
+			"definitions": schema.ListNestedAttribute{
+				Description: "Skill types and the configuration they accept",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "Skill type, the value of type in controlplane_skill",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Display name of the skill type",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the skill type",
+							Computed:    true,
+						},
+						"icon": schema.StringAttribute{
+							Description: "Icon name",
+							Computed:    true,
+						},
+						"custom": schema.BoolAttribute{
+							Description: "Whether the skill type was registered by the organization rather than built in",
+							Computed:    true,
+						},
+						"default_configuration": schema.StringAttribute{
+							Description: "Configuration applied by default to skills of this type, as JSON string",
+							Computed:    true,
+						},
+						"configuration_schema": schema.StringAttribute{
+							Description: "JSON Schema the configuration of skills of this type must match, as JSON string",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *skillDefinitionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *skillDefinitionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data skillDefinitionsDataSourceModel
+
+	definitions, err := d.client.ListSkillDefinitions(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing skill definitions", err.Error())
+		return
+	}
+
+	data.Definitions = make([]skillDefinitionModel, 0, len(definitions))
+	for _, definition := range definitions {
+		var model skillDefinitionModel
+		resp.Diagnostics.Append(populateSkillDefinitionModel(&model, definition)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Definitions = append(data.Definitions, model)
+	}
+
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// populateSkillDefinitionModel copies the fields of an API skill definition into a data source model
+func populateSkillDefinitionModel(model *skillDefinitionModel, definition *entities.SkillDefinition) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.Type = types.StringValue(string(definition.Type))
+	model.Name = types.StringValue(definition.Name)
+	model.Description = types.StringValue(definition.Description)
+	model.Icon = types.StringValue(definition.Icon)
+	model.Custom = types.BoolValue(definition.Custom)
+
+	defaults, err := toJSONString(definition.DefaultConfiguration)
+	if err != nil {
+		diags.AddError("Error converting default configuration", err.Error())
+		return diags
+	}
+	model.DefaultConfiguration = types.StringValue(defaults)
+
+	model.ConfigurationSchema = types.StringNull()
+	if definition.ConfigurationSchema != nil {
+		configurationSchema, err := toJSONString(definition.ConfigurationSchema)
+		if err != nil {
+			diags.AddError("Error converting configuration schema", err.Error())
+			return diags
+		}
+		model.ConfigurationSchema = types.StringValue(configurationSchema)
+	}
+
+	return diags
+}
@@ -3,10 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -17,6 +19,8 @@ import (
 
 var _ resource.Resource = (*skillResource)(nil)
 var _ resource.ResourceWithImportState = (*skillResource)(nil)
+var _ resource.ResourceWithValidateConfig = (*skillResource)(nil)
+var _ resource.ResourceWithModifyPlan = (*skillResource)(nil)
 
 func NewSkillResource() resource.Resource {
 	return &skillResource{}
@@ -34,6 +38,10 @@ type skillResourceModel struct {
 	Icon          types.String `tfsdk:"icon"`
 	Enabled       types.Bool   `tfsdk:"enabled"`
 	Configuration types.String `tfsdk:"configuration"`
+	Shell         types.Object `tfsdk:"shell"`
+	FileSystem    types.Object `tfsdk:"file_system"`
+	Docker        types.Object `tfsdk:"docker"`
+	Python        types.Object `tfsdk:"python"`
 	CreatedAt     types.String `tfsdk:"created_at"`
 	UpdatedAt     types.String `tfsdk:"updated_at"`
 }
@@ -58,8 +66,11 @@ func (r *skillResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Required:    true,
 			},
 			"type": schema.StringAttribute{
-				Description: "Skill type (file_system, shell, docker, python, file_generation, custom)",
+				Description: "Skill type (file_system, shell, docker, python, file_generation, custom). Changing it replaces the skill",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"description": schema.StringAttribute{
 				Description: "Skill description",
@@ -72,9 +83,11 @@ func (r *skillResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"enabled": schema.BoolAttribute{
 				Description: "Whether the skill is enabled",
 				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
 			},
 			"configuration": schema.StringAttribute{
-				Description: "Skill configuration as JSON string",
+				Description: "Skill configuration as JSON string. Settings of the typed block matching type are merged into it",
 				Optional:    true,
 			},
 			"created_at": schema.StringAttribute{
@@ -86,6 +99,79 @@ func (r *skillResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Computed:    true,
 			},
 		},
+		Blocks: skillConfigurationSchemaBlocks(),
+	}
+}
+
+// ValidateConfig checks that only the configuration block matching the skill type is set
+func (r *skillResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config skillResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateSkillConfigurationBlocks(ctx, &config)...)
+}
+
+// ModifyPlan checks type and configuration against the skill definitions of the Control Plane. They
+// are fetched from the API, so unlike ValidateConfig this only runs once the provider is configured.
+func (r *skillResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan skillResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Type.IsUnknown() {
+		return
+	}
+
+	definitions, err := r.client.ListSkillDefinitions(ctx)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Skill Configuration Not Validated",
+			fmt.Sprintf("Could not fetch the skill definitions to check type and configuration against: %s", err))
+		return
+	}
+
+	skillType := plan.Type.ValueString()
+	index := slices.IndexFunc(definitions, func(d *entities.SkillDefinition) bool { return string(d.Type) == skillType })
+	if index < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("type"), "Unsupported Skill Type",
+			fmt.Sprintf("type %q is not a skill type the Control Plane supports. The controlplane_skill_definitions data source lists the supported types", skillType))
+		return
+	}
+
+	// Values only known after apply would be reported as missing
+	if plan.Configuration.IsUnknown() || hasUnknownValues(plan.configurationBlocks()[skillType]) {
+		return
+	}
+
+	config, diags := skillConfiguration(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || config == nil {
+		return
+	}
+
+	problems, err := validateSkillConfigurationSchema(definitions[index], config)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Skill Configuration Not Validated",
+			fmt.Sprintf("The configuration schema of skill type %q could not be used: %s", skillType, err))
+		return
+	}
+
+	blockValues := map[string]interface{}{}
+	if block, ok := plan.configurationBlocks()[skillType]; ok && !block.IsNull() {
+		blockValues, diags = skillBlockValues(ctx, block)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	for _, problem := range problems {
+		attribute := path.Root("configuration")
+		if _, ok := blockValues[problem.Key]; ok {
+			attribute = path.Root(skillType).AtName(problem.Key)
+		}
+		resp.Diagnostics.AddAttributeError(attribute, "Invalid Skill Configuration", problem.Message)
 	}
 }
 
@@ -129,16 +215,14 @@ func (r *skillResource) Create(ctx context.Context, req resource.CreateRequest,
 		createReq.Icon = plan.Icon.ValueString()
 	}
 
-	if !plan.Configuration.IsNull() {
-		config, err := parseJSON(plan.Configuration.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Invalid Configuration", fmt.Sprintf("Failed to parse configuration JSON: %s", err))
-			return
-		}
-		createReq.Configuration = config
+	config, diags := skillConfiguration(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	createReq.Configuration = config
 
-	skill, err := r.client.CreateSkill(createReq)
+	skill, err := r.client.CreateSkill(ctx, createReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating skill", err.Error())
 		return
@@ -147,6 +231,7 @@ func (r *skillResource) Create(ctx context.Context, req resource.CreateRequest,
 	plan.ID = types.StringValue(skill.ID)
 	plan.Name = types.StringValue(skill.Name)
 	plan.Type = types.StringValue(string(skill.Type))
+	plan.Enabled = types.BoolValue(skill.Enabled)
 
 	if skill.Description != nil {
 		plan.Description = types.StringValue(*skill.Description)
@@ -172,7 +257,7 @@ func (r *skillResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	skill, err := r.client.GetSkill(state.ID.ValueString())
+	skill, err := r.client.GetSkill(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading skill", err.Error())
 		return
@@ -180,6 +265,7 @@ func (r *skillResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	state.ID = types.StringValue(skill.ID)
 	state.Name = types.StringValue(skill.Name)
+	// A type that no longer matches the configuration plans a replacement, since updates cannot change it
 	state.Type = types.StringValue(string(skill.Type))
 	state.Enabled = types.BoolValue(skill.Enabled)
 
@@ -220,16 +306,19 @@ func (r *skillResource) Update(ctx context.Context, req resource.UpdateRequest,
 	enabled := plan.Enabled.ValueBool()
 	updateReq.Enabled = &enabled
 
-	if !plan.Configuration.IsNull() {
-		config, err := parseJSON(plan.Configuration.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError("Invalid Configuration", fmt.Sprintf("Failed to parse configuration JSON: %s", err))
-			return
-		}
-		updateReq.Configuration = config
+	config, diags := skillConfiguration(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updateReq.Configuration = config
+
+	resp.Diagnostics.Append(checkNotModifiedSinceRefresh(ctx, r.client, req.State, "skill", (*clients.Client).GetSkill)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	skill, err := r.client.UpdateSkill(plan.ID.ValueString(), updateReq)
+	skill, err := r.client.UpdateSkill(ctx, plan.ID.ValueString(), updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating skill", err.Error())
 		return
@@ -267,13 +356,16 @@ func (r *skillResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.DeleteSkill(state.ID.ValueString())
+	err := r.client.DeleteSkill(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting skill", err.Error())
 		return
 	}
 }
 
+// ImportState accepts either the skill ID or the skill name
 func (r *skillResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByLookup(ctx, req, resp, "skill", func(name string) (string, error) {
+		return lookupSkillID(ctx, r.client, name)
+	})
 }
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+)
+
+// fileSystemDefinitions lists a file_system skill type whose configuration requires base_dir
+const fileSystemDefinitions = `[{
+	"type": "file_system",
+	"name": "File System",
+	"custom": false,
+	"configuration_schema": {
+		"type": "object",
+		"required": ["base_dir"],
+		"properties": {"base_dir": {"type": "string"}, "read_only": {"type": "boolean"}}
+	}
+}]`
+
+// skillPlan returns a plan for a file_system skill whose file_system block has the given attributes
+func skillPlan(t *testing.T, schema resource.SchemaResponse, block map[string]tftypes.Value) tfsdk.Plan {
+	t.Helper()
+
+	ctx := context.Background()
+	objectType := schema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	values["name"] = tftypes.NewValue(tftypes.String, "files")
+	values["type"] = tftypes.NewValue(tftypes.String, "file_system")
+	values["enabled"] = tftypes.NewValue(tftypes.Bool, true)
+	values["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	blockType := objectType.AttributeTypes["file_system"].(tftypes.Object)
+	blockValues := map[string]tftypes.Value{}
+	for name, attributeType := range blockType.AttributeTypes {
+		blockValues[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range block {
+		blockValues[name] = value
+	}
+	values["file_system"] = tftypes.NewValue(blockType, blockValues)
+
+	return tfsdk.Plan{Schema: schema.Schema, Raw: tftypes.NewValue(objectType, values)}
+}
+
+func TestSkillModifyPlanSchemaValidation(t *testing.T) {
+	ctx := context.Background()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fileSystemDefinitions))
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("KUBIYA_CONTROL_PLANE_BASE_URL", server.URL)
+	client, err := clients.New("test-api-key")
+	require.NoError(t, err)
+
+	r := &skillResource{client: client}
+	var schema resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schema)
+	require.False(t, schema.Diagnostics.HasError())
+
+	modifyPlan := func(block map[string]tftypes.Value) *resource.ModifyPlanResponse {
+		plan := skillPlan(t, schema, block)
+		resp := &resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan}, resp)
+		return resp
+	}
+
+	// base_dir is only known after apply, e.g. when it refers to another resource
+	resp := modifyPlan(map[string]tftypes.Value{"base_dir": tftypes.NewValue(tftypes.String, tftypes.UnknownValue)})
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	resp = modifyPlan(map[string]tftypes.Value{
+		"read_only":     tftypes.NewValue(tftypes.Bool, true),
+		"allowed_paths": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, tftypes.UnknownValue)}),
+	})
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	resp = modifyPlan(map[string]tftypes.Value{"read_only": tftypes.NewValue(tftypes.Bool, true)})
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Invalid Skill Configuration", resp.Diagnostics.Errors()[0].Summary())
+
+	resp = modifyPlan(map[string]tftypes.Value{"base_dir": tftypes.NewValue(tftypes.String, "/app")})
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	assert.Equal(t, int32(1), requests.Load(), "the skill definitions are fetched once per provider")
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+)
+
+var _ datasource.DataSource = (*skillsDataSource)(nil)
+
+func NewSkillsDataSource() datasource.DataSource {
+	return &skillsDataSource{}
+}
+
+type skillsDataSource struct {
+	client *clients.Client
+}
+
+type skillsDataSourceModel struct {
+	NameRegex types.String           `tfsdk:"name_regex"`
+	Type      types.String           `tfsdk:"type"`
+	Enabled   types.Bool             `tfsdk:"enabled"`
+	Skills    []skillDataSourceModel `tfsdk:"skills"`
+}
+
+func (d *skillsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_skills"
+}
+
+func (d *skillsDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches Skills from the Control Plane, optionally filtered.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": nameRegexAttribute("skills"),
+			"type": schema.StringAttribute{
+				Description: "Only return skills of this type",
+				Optional:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Only return skills that are enabled (true) or disabled (false)",
+				Optional:    true,
+			},
+			"skills": schema.ListNestedAttribute{
+				Description: "List of skills matching the filters",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: nestedDataSourceAttributes(ctx, NewSkillDataSource()),
+				},
+			},
+		},
+	}
+}
+
+func (d *skillsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *skillsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data skillsDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := compileNameRegex(data.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid name_regex", err.Error())
+		return
+	}
+
+	opts := &clients.ListOptions{Filters: map[string]string{
+		"type":    data.Type.ValueString(),
+		"enabled": boolFilterValue(data.Enabled),
+	}}
+
+	data.Skills = []skillDataSourceModel{}
+	for skill, err := range d.client.IterSkills(ctx, opts) {
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing skills", err.Error())
+			return
+		}
+
+		if !matchesNameRegex(nameRegex, skill.Name) ||
+			!matchesString(data.Type, string(skill.Type)) ||
+			!matchesBool(data.Enabled, skill.Enabled) {
+			continue
+		}
+
+		var skillModel skillDataSourceModel
+		resp.Diagnostics.Append(populateSkillDataSourceModel(ctx, &skillModel, skill)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Skills = append(data.Skills, skillModel)
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
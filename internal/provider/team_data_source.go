@@ -4,14 +4,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
 )
 
-var _ datasource.DataSource = (*teamDataSource)(nil)
+var (
+	_ datasource.DataSource                     = (*teamDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*teamDataSource)(nil)
+)
 
 func NewTeamDataSource() datasource.DataSource {
 	return &teamDataSource{}
@@ -22,16 +29,25 @@ type teamDataSource struct {
 }
 
 type teamDataSourceModel struct {
-	ID                   types.String `tfsdk:"id"`
-	Name                 types.String `tfsdk:"name"`
-	Description          types.String `tfsdk:"description"`
-	Status               types.String `tfsdk:"status"`
-	Runtime              types.String `tfsdk:"runtime"`
-	Configuration        types.String `tfsdk:"configuration"`
-	SkillIDs             types.List   `tfsdk:"skill_ids"`
-	ExecutionEnvironment types.String `tfsdk:"execution_environment"`
-	CreatedAt            types.String `tfsdk:"created_at"`
-	UpdatedAt            types.String `tfsdk:"updated_at"`
+	ID                   types.String     `tfsdk:"id"`
+	Name                 types.String     `tfsdk:"name"`
+	Description          types.String     `tfsdk:"description"`
+	Status               types.String     `tfsdk:"status"`
+	Runtime              types.String     `tfsdk:"runtime"`
+	Configuration        types.String     `tfsdk:"configuration"`
+	SkillIDs             types.List       `tfsdk:"skill_ids"`
+	ExecutionEnvironment types.String     `tfsdk:"execution_environment"`
+	Agents               []teamAgentModel `tfsdk:"agents"`
+	CreatedAt            types.String     `tfsdk:"created_at"`
+	UpdatedAt            types.String     `tfsdk:"updated_at"`
+}
+
+type teamAgentModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Status  types.String `tfsdk:"status"`
+	Runtime types.String `tfsdk:"runtime"`
+	Role    types.String `tfsdk:"role"`
 }
 
 func (d *teamDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -43,11 +59,13 @@ func (d *teamDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 		Description: "Fetches an existing Team from the Control Plane.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Team ID to lookup",
-				Required:    true,
+				Description: "Team ID to lookup. Exactly one of id or name must be set",
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "Team name",
+				Description: "Team name to lookup. Exactly one of id or name must be set",
+				Optional:    true,
 				Computed:    true,
 			},
 			"description": schema.StringAttribute{
@@ -75,6 +93,34 @@ func (d *teamDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 				Description: "Execution environment configuration as JSON string",
 				Computed:    true,
 			},
+			"agents": schema.ListNestedAttribute{
+				Description: "Agents in the team. Only set when a single team is looked up; controlplane_teams leaves it null",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Agent ID",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Agent name",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Agent status",
+							Computed:    true,
+						},
+						"runtime": schema.StringAttribute{
+							Description: "Agent runtime",
+							Computed:    true,
+						},
+						"role": schema.StringAttribute{
+							Description: "Role of the agent in the team (member, leader)",
+							Computed:    true,
+						},
+					},
+				},
+			},
 			"created_at": schema.StringAttribute{
 				Description: "Timestamp when the team was created",
 				Computed:    true,
@@ -87,6 +133,15 @@ func (d *teamDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 	}
 }
 
+func (d *teamDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
 func (d *teamDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -112,39 +167,62 @@ func (d *teamDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	team, err := d.client.GetTeam(config.ID.ValueString())
+	id := config.ID.ValueString()
+	if config.ID.IsNull() {
+		var err error
+		id, err = lookupTeamID(ctx, d.client, config.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading team", err.Error())
+			return
+		}
+	}
+
+	team, err := d.client.GetTeam(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading team", err.Error())
 		return
 	}
 
-	// Map response to state
-	config.Name = types.StringValue(team.Name)
+	resp.Diagnostics.Append(populateTeamDataSourceModel(ctx, &config, team)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// populateTeamDataSourceModel copies the fields of an API team into a data source model
+func populateTeamDataSourceModel(ctx context.Context, model *teamDataSourceModel, team *entities.Team) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(team.ID)
+	model.Name = types.StringValue(team.Name)
 
 	if team.Description != nil {
-		config.Description = types.StringValue(*team.Description)
+		model.Description = types.StringValue(*team.Description)
 	} else {
-		config.Description = types.StringNull()
+		model.Description = types.StringNull()
 	}
 
-	config.Status = types.StringValue(string(team.Status))
+	model.Status = types.StringValue(string(team.Status))
 
 	if team.Runtime != nil {
-		config.Runtime = types.StringValue(*team.Runtime)
+		model.Runtime = types.StringValue(*team.Runtime)
 	} else {
-		config.Runtime = types.StringNull()
+		model.Runtime = types.StringNull()
 	}
 
 	// Convert configuration to JSON string
 	if len(team.Configuration) > 0 {
 		configJSON, err := toJSONString(team.Configuration)
 		if err != nil {
-			resp.Diagnostics.AddError("Error converting configuration", err.Error())
-			return
+			diags.AddError("Error converting configuration", err.Error())
+			return diags
 		}
-		config.Configuration = types.StringValue(configJSON)
+		model.Configuration = types.StringValue(configJSON)
 	} else {
-		config.Configuration = types.StringNull()
+		model.Configuration = types.StringNull()
 	}
 
 	// Convert skill IDs to list
@@ -153,37 +231,51 @@ func (d *teamDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		for i, id := range team.SkillIDs {
 			skillList[i] = types.StringValue(id)
 		}
-		listVal, diags := types.ListValueFrom(ctx, types.StringType, skillList)
-		resp.Diagnostics.Append(diags...)
-		config.SkillIDs = listVal
+		listVal, listDiags := types.ListValueFrom(ctx, types.StringType, skillList)
+		diags.Append(listDiags...)
+		model.SkillIDs = listVal
 	} else {
-		config.SkillIDs = types.ListNull(types.StringType)
+		model.SkillIDs = types.ListNull(types.StringType)
 	}
 
 	// Convert execution environment to JSON string
 	if len(team.ExecutionEnvironment) > 0 {
 		execEnvJSON, err := toJSONString(team.ExecutionEnvironment)
 		if err != nil {
-			resp.Diagnostics.AddError("Error converting execution_environment", err.Error())
-			return
+			diags.AddError("Error converting execution_environment", err.Error())
+			return diags
 		}
-		config.ExecutionEnvironment = types.StringValue(execEnvJSON)
+		model.ExecutionEnvironment = types.StringValue(execEnvJSON)
 	} else {
-		config.ExecutionEnvironment = types.StringNull()
+		model.ExecutionEnvironment = types.StringNull()
+	}
+
+	// Teams fetched from the list endpoint come without agents
+	model.Agents = nil
+	if team.Agents != nil {
+		model.Agents = make([]teamAgentModel, 0, len(team.Agents))
+		for _, agent := range team.Agents {
+			model.Agents = append(model.Agents, teamAgentModel{
+				ID:      types.StringValue(agent.ID),
+				Name:    types.StringValue(agent.Name),
+				Status:  types.StringValue(string(agent.Status)),
+				Runtime: types.StringValue(string(agent.Runtime)),
+				Role:    types.StringValue(string(agent.Role)),
+			})
+		}
 	}
 
 	if team.CreatedAt != nil {
-		config.CreatedAt = types.StringValue(team.CreatedAt.String())
+		model.CreatedAt = types.StringValue(team.CreatedAt.String())
 	} else {
-		config.CreatedAt = types.StringNull()
+		model.CreatedAt = types.StringNull()
 	}
 
 	if team.UpdatedAt != nil {
-		config.UpdatedAt = types.StringValue(team.UpdatedAt.String())
+		model.UpdatedAt = types.StringValue(team.UpdatedAt.String())
 	} else {
-		config.UpdatedAt = types.StringNull()
+		model.UpdatedAt = types.StringNull()
 	}
 
-	diags = resp.State.Set(ctx, &config)
-	resp.Diagnostics.Append(diags...)
+	return diags
 }
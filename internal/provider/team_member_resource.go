@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
+	"terraform-provider-kubiya-control-plane/pkg/controlplane"
+)
+
+// teamRoles lists the roles an agent can have in its team
+var teamRoles = []string{
+	string(entities.TeamRoleMember),
+	string(entities.TeamRoleLeader),
+}
+
+var (
+	_ resource.Resource                   = (*teamMemberResource)(nil)
+	_ resource.ResourceWithImportState    = (*teamMemberResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*teamMemberResource)(nil)
+)
+
+func NewTeamMemberResource() resource.Resource {
+	return &teamMemberResource{}
+}
+
+type teamMemberResource struct {
+	client *clients.Client
+}
+
+type teamMemberResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	TeamID  types.String `tfsdk:"team_id"`
+	AgentID types.String `tfsdk:"agent_id"`
+	Role    types.String `tfsdk:"role"`
+}
+
+func (r *teamMemberResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_member"
+}
+
+func (r *teamMemberResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Adds an Agent to a Team. An agent belongs to at most one team, so adding it moves it out of the team it was in. Do not combine with controlplane_team_members for the same team, or with team_id on the agent.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Membership ID, <team_id>/<agent_id>",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"team_id": schema.StringAttribute{
+				Description: "ID of the team",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"agent_id": schema.StringAttribute{
+				Description: "ID of the agent to add to the team",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Description: "Role of the agent in the team (" + strings.Join(teamRoles, ", ") + "). A team has at most one leader. Default: member",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(string(entities.TeamRoleMember)),
+			},
+		},
+	}
+}
+
+func (r *teamMemberResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var role types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("role"), &role)...)
+	if resp.Diagnostics.HasError() || role.IsNull() || role.IsUnknown() {
+		return
+	}
+
+	if !slices.Contains(teamRoles, role.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("role"), "Invalid Team Role",
+			fmt.Sprintf("role must be one of %s, got %q", quotedList(teamRoles), role.ValueString()))
+	}
+}
+
+func (r *teamMemberResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *teamMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan teamMemberResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role := entities.TeamRole(plan.Role.ValueString())
+	member, err := r.client.AddTeamMember(ctx, plan.TeamID.ValueString(), plan.AgentID.ValueString(), &entities.TeamMemberRequest{Role: &role})
+	if err != nil {
+		resp.Diagnostics.AddError("Error adding agent to team", err.Error())
+		return
+	}
+
+	populateTeamMemberModel(&plan, plan.TeamID.ValueString(), member)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *teamMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state teamMemberResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, err := r.client.GetTeam(ctx, state.TeamID.ValueString())
+	if err != nil {
+		// The membership went away with its team
+		if controlplane.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error reading team member", err.Error())
+		return
+	}
+
+	// An agent that left the team, or moved to another one, is no longer a member: plan to add it again
+	index := slices.IndexFunc(team.Agents, func(m *entities.TeamMember) bool { return m.ID == state.AgentID.ValueString() })
+	if index < 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	populateTeamMemberModel(&state, team.ID, team.Agents[index])
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update changes the role: every other configurable attribute forces a new membership
+func (r *teamMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan teamMemberResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role := entities.TeamRole(plan.Role.ValueString())
+	member, err := r.client.UpdateTeamMember(ctx, plan.TeamID.ValueString(), plan.AgentID.ValueString(), &entities.TeamMemberRequest{Role: &role})
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating team member", err.Error())
+		return
+	}
+
+	populateTeamMemberModel(&plan, plan.TeamID.ValueString(), member)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *teamMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state teamMemberResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.RemoveTeamMember(ctx, state.TeamID.ValueString(), state.AgentID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error removing agent from team", err.Error())
+		return
+	}
+}
+
+// ImportState accepts <team>/<agent>, where each part is an ID or a name
+func (r *teamMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || slices.Contains(parts, "") {
+		resp.Diagnostics.AddError("Error importing team member",
+			fmt.Sprintf("Could not resolve import ID %q: expected <team>/<agent>, where each part is an ID or a name", req.ID))
+		return
+	}
+
+	teamID, agentID := parts[0], parts[1]
+	var err error
+	if !isUUID(teamID) {
+		teamID, err = lookupTeamID(ctx, r.client, teamID)
+	}
+	if err == nil && !isUUID(agentID) {
+		agentID, err = lookupAgentID(ctx, r.client, agentID)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing team member", fmt.Sprintf("Could not resolve import ID %q: %s", req.ID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), teamID+"/"+agentID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("team_id"), teamID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("agent_id"), agentID)...)
+}
+
+// populateTeamMemberModel copies an API team member into a resource model
+func populateTeamMemberModel(model *teamMemberResourceModel, teamID string, member *entities.TeamMember) {
+	model.ID = types.StringValue(teamID + "/" + member.ID)
+	model.TeamID = types.StringValue(teamID)
+	model.AgentID = types.StringValue(member.ID)
+	model.Role = types.StringValue(string(member.Role))
+}
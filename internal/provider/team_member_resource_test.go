@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+)
+
+// notFoundClient returns a client whose every request is answered with 404
+func notFoundClient(t *testing.T) *clients.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"detail":"Not found"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("KUBIYA_CONTROL_PLANE_BASE_URL", server.URL)
+	client, err := clients.New("test-api-key")
+	require.NoError(t, err)
+
+	return client
+}
+
+// resourceState returns the state of a resource with the given string attributes; the others are null
+func resourceState(t *testing.T, r resource.Resource, attributes map[string]string) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+	var schema resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schema)
+	require.False(t, schema.Diagnostics.HasError())
+
+	objectType := schema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range attributes {
+		values[name] = tftypes.NewValue(tftypes.String, value)
+	}
+
+	return tfsdk.State{Schema: schema.Schema, Raw: tftypes.NewValue(objectType, values)}
+}
+
+func TestTeamMemberReadTeamNotFound(t *testing.T) {
+	r := &teamMemberResource{client: notFoundClient(t)}
+	state := resourceState(t, r, map[string]string{"id": "t1/a1", "team_id": "t1", "agent_id": "a1"})
+
+	resp := &resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	assert.True(t, resp.State.Raw.IsNull(), "a membership whose team is gone is removed from state")
+}
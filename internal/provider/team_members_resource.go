@@ -0,0 +1,347 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
+)
+
+var (
+	_ resource.Resource                   = (*teamMembersResource)(nil)
+	_ resource.ResourceWithImportState    = (*teamMembersResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*teamMembersResource)(nil)
+)
+
+func NewTeamMembersResource() resource.Resource {
+	return &teamMembersResource{}
+}
+
+type teamMembersResource struct {
+	client *clients.Client
+}
+
+type teamMembersResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	TeamID  types.String `tfsdk:"team_id"`
+	Members types.Set    `tfsdk:"members"`
+}
+
+type teamMembersEntryModel struct {
+	AgentID types.String `tfsdk:"agent_id"`
+	Role    types.String `tfsdk:"role"`
+}
+
+var teamMembersEntryAttrTypes = map[string]attr.Type{
+	"agent_id": types.StringType,
+	"role":     types.StringType,
+}
+
+func (r *teamMembersResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_members"
+}
+
+func (r *teamMembersResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the complete list of Agents in a Team. Agents not listed are removed from the team, and agents listed are moved into it from any other team. Do not combine with controlplane_team_member for the same team, or with team_id on its agents.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the team",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"team_id": schema.StringAttribute{
+				Description: "ID of the team",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"members": schema.SetNestedAttribute{
+				Description: "Agents in the team. An empty set removes every agent from the team",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"agent_id": schema.StringAttribute{
+							Description: "ID of the agent",
+							Required:    true,
+						},
+						"role": schema.StringAttribute{
+							Description: "Role of the agent in the team (" + strings.Join(teamRoles, ", ") + "). Default: member",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString(string(entities.TeamRoleMember)),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the roles, that an agent is listed once and that there is at most one leader
+func (r *teamMembersResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config teamMembersResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Members.IsNull() || config.Members.IsUnknown() {
+		return
+	}
+
+	var entries []teamMembersEntryModel
+	resp.Diagnostics.Append(config.Members.ElementsAs(ctx, &entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := map[string]bool{}
+	leaders := 0
+	for _, entry := range entries {
+		if !entry.Role.IsNull() && !entry.Role.IsUnknown() {
+			switch role := entry.Role.ValueString(); {
+			case !slices.Contains(teamRoles, role):
+				resp.Diagnostics.AddAttributeError(path.Root("members"), "Invalid Team Role",
+					fmt.Sprintf("role must be one of %s, got %q", quotedList(teamRoles), role))
+			case role == string(entities.TeamRoleLeader):
+				leaders++
+			}
+		}
+
+		if entry.AgentID.IsUnknown() {
+			continue
+		}
+		if seen[entry.AgentID.ValueString()] {
+			resp.Diagnostics.AddAttributeError(path.Root("members"), "Duplicate Team Member",
+				fmt.Sprintf("Agent %q is listed more than once; list each agent once with its role", entry.AgentID.ValueString()))
+		}
+		seen[entry.AgentID.ValueString()] = true
+	}
+
+	if leaders > 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("members"), "Multiple Team Leaders",
+			fmt.Sprintf("A team has at most one leader, but %d members have role \"leader\"", leaders))
+	}
+}
+
+func (r *teamMembersResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *teamMembersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan teamMembersResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *teamMembersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state teamMembersResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported state only has the ID, which is the team ID
+	team, err := r.client.GetTeam(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading team members", err.Error())
+		return
+	}
+
+	entries := make([]teamMembersEntryModel, 0, len(team.Agents))
+	for _, member := range team.Agents {
+		entries = append(entries, teamMembersEntryModel{
+			AgentID: types.StringValue(member.ID),
+			Role:    types.StringValue(string(member.Role)),
+		})
+	}
+
+	state.ID = types.StringValue(team.ID)
+	state.TeamID = types.StringValue(team.ID)
+	state.Members, diags = types.SetValueFrom(ctx, types.ObjectType{AttrTypes: teamMembersEntryAttrTypes}, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *teamMembersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan teamMembersResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the agents in the state from the team. Agents that already left it are skipped.
+func (r *teamMembersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state teamMembersResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var entries []teamMembersEntryModel
+	resp.Diagnostics.Append(state.Members.ElementsAs(ctx, &entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	teamID := state.TeamID.ValueString()
+	current, err := r.currentRoles(ctx, teamID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error removing team members", err.Error())
+		return
+	}
+
+	for _, entry := range entries {
+		agentID := entry.AgentID.ValueString()
+		if _, ok := current[agentID]; !ok {
+			continue
+		}
+		if err := r.client.RemoveTeamMember(ctx, teamID, agentID); err != nil {
+			resp.Diagnostics.AddError("Error removing team members", fmt.Sprintf("agent %q: %s", agentID, err))
+			return
+		}
+	}
+}
+
+// ImportState accepts the team ID or name
+func (r *teamMembersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importStateByLookup(ctx, req, resp, "team members", func(name string) (string, error) {
+		return lookupTeamID(ctx, r.client, name)
+	})
+}
+
+// reconcile removes, adds and updates agents so that the team has exactly the members of the model.
+// Agents are removed first, so that a leader who leaves makes room for a new one in the same apply.
+func (r *teamMembersResource) reconcile(ctx context.Context, model *teamMembersResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var entries []teamMembersEntryModel
+	diags.Append(model.Members.ElementsAs(ctx, &entries, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	desired := make(map[string]entities.TeamRole, len(entries))
+	for _, entry := range entries {
+		desired[entry.AgentID.ValueString()] = entities.TeamRole(entry.Role.ValueString())
+	}
+
+	teamID := model.TeamID.ValueString()
+	current, err := r.currentRoles(ctx, teamID)
+	if err != nil {
+		diags.AddError("Error reading team members", err.Error())
+		return diags
+	}
+
+	for _, agentID := range slices.Sorted(maps.Keys(current)) {
+		if _, ok := desired[agentID]; ok {
+			continue
+		}
+		if err := r.client.RemoveTeamMember(ctx, teamID, agentID); err != nil {
+			diags.AddError("Error removing agent from team", fmt.Sprintf("agent %q: %s", agentID, err))
+			return diags
+		}
+	}
+
+	// The leader goes last, after the former leader has been demoted
+	var agentIDs, leaders []string
+	for _, agentID := range slices.Sorted(maps.Keys(desired)) {
+		if desired[agentID] == entities.TeamRoleLeader {
+			leaders = append(leaders, agentID)
+		} else {
+			agentIDs = append(agentIDs, agentID)
+		}
+	}
+	agentIDs = append(agentIDs, leaders...)
+
+	for _, agentID := range agentIDs {
+		role := desired[agentID]
+		currentRole, ok := current[agentID]
+		switch {
+		case !ok:
+			if _, err := r.client.AddTeamMember(ctx, teamID, agentID, &entities.TeamMemberRequest{Role: &role}); err != nil {
+				diags.AddError("Error adding agent to team", fmt.Sprintf("agent %q: %s", agentID, err))
+				return diags
+			}
+		case currentRole != role:
+			if _, err := r.client.UpdateTeamMember(ctx, teamID, agentID, &entities.TeamMemberRequest{Role: &role}); err != nil {
+				diags.AddError("Error updating team member", fmt.Sprintf("agent %q: %s", agentID, err))
+				return diags
+			}
+		}
+	}
+
+	model.ID = types.StringValue(teamID)
+
+	return diags
+}
+
+// currentRoles returns the role of every agent in the team, keyed by agent ID
+func (r *teamMembersResource) currentRoles(ctx context.Context, teamID string) (map[string]entities.TeamRole, error) {
+	team, err := r.client.GetTeam(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make(map[string]entities.TeamRole, len(team.Agents))
+	for _, member := range team.Agents {
+		roles[member.ID] = member.Role
+	}
+
+	return roles, nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -172,7 +171,7 @@ func (r *teamResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	// Create team
-	team, err := r.client.CreateTeam(createReq)
+	team, err := r.client.CreateTeam(ctx, createReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating team", err.Error())
 		return
@@ -218,7 +217,7 @@ func (r *teamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	team, err := r.client.GetTeam(state.ID.ValueString())
+	team, err := r.client.GetTeam(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading team", err.Error())
 		return
@@ -293,8 +292,13 @@ func (r *teamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		updateReq.Runtime = &runtime
 	}
 
+	resp.Diagnostics.Append(checkNotModifiedSinceRefresh(ctx, r.client, req.State, "team", (*clients.Client).GetTeam)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Update team
-	team, err := r.client.UpdateTeam(state.ID.ValueString(), updateReq)
+	team, err := r.client.UpdateTeam(ctx, state.ID.ValueString(), updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating team", err.Error())
 		return
@@ -342,13 +346,16 @@ func (r *teamResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := r.client.DeleteTeam(state.ID.ValueString())
+	err := r.client.DeleteTeam(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting team", err.Error())
 		return
 	}
 }
 
+// ImportState accepts either the team ID or the team name
 func (r *teamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByLookup(ctx, req, resp, "team", func(name string) (string, error) {
+		return lookupTeamID(ctx, r.client, name)
+	})
 }
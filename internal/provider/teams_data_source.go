@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+)
+
+var _ datasource.DataSource = (*teamsDataSource)(nil)
+
+func NewTeamsDataSource() datasource.DataSource {
+	return &teamsDataSource{}
+}
+
+type teamsDataSource struct {
+	client *clients.Client
+}
+
+type teamsDataSourceModel struct {
+	NameRegex types.String          `tfsdk:"name_regex"`
+	Status    types.String          `tfsdk:"status"`
+	Runtime   types.String          `tfsdk:"runtime"`
+	Teams     []teamDataSourceModel `tfsdk:"teams"`
+}
+
+func (d *teamsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_teams"
+}
+
+func (d *teamsDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches Teams from the Control Plane, optionally filtered.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": nameRegexAttribute("teams"),
+			"status": schema.StringAttribute{
+				Description: "Only return teams with this status (active, inactive, archived)",
+				Optional:    true,
+			},
+			"runtime": schema.StringAttribute{
+				Description: "Only return teams with this runtime (default or claude_code)",
+				Optional:    true,
+			},
+			"teams": schema.ListNestedAttribute{
+				Description: "List of teams matching the filters",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: nestedDataSourceAttributes(ctx, NewTeamDataSource()),
+				},
+			},
+		},
+	}
+}
+
+func (d *teamsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *teamsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data teamsDataSourceModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := compileNameRegex(data.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid name_regex", err.Error())
+		return
+	}
+
+	opts := &clients.ListOptions{Filters: map[string]string{
+		"status":  data.Status.ValueString(),
+		"runtime": data.Runtime.ValueString(),
+	}}
+
+	data.Teams = []teamDataSourceModel{}
+	for team, err := range d.client.IterTeams(ctx, opts) {
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing teams", err.Error())
+			return
+		}
+
+		runtime := ""
+		if team.Runtime != nil {
+			runtime = *team.Runtime
+		}
+
+		if !matchesNameRegex(nameRegex, team.Name) ||
+			!matchesString(data.Status, string(team.Status)) ||
+			!matchesString(data.Runtime, runtime) {
+			continue
+		}
+
+		var teamModel teamDataSourceModel
+		resp.Diagnostics.Append(populateTeamDataSourceModel(ctx, &teamModel, team)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Teams = append(data.Teams, teamModel)
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
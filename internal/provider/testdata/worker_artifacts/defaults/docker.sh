@@ -0,0 +1,7 @@
+docker run -d --restart unless-stopped --name 'default-worker' \
+  -e KUBIYA_WORKER_TOKEN="$KUBIYA_WORKER_TOKEN" \
+  -e CONTROL_PLANE_URL='https://control-plane.kubiya.ai' \
+  -e WORKER_QUEUE_ID='3f9a1c52-7d0e-4b8a-9c61-2e5f8d4a7b10' \
+  -e TASK_QUEUE_NAME='production.default' \
+  -e HEARTBEAT_INTERVAL='0' \
+  'ghcr.io/kubiyabot/agent-worker:latest'
\ No newline at end of file
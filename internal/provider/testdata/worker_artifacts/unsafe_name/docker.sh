@@ -0,0 +1,7 @@
+docker run -d --restart unless-stopped --name 'gpu-workers-v2-eu-worker' \
+  -e KUBIYA_WORKER_TOKEN="$KUBIYA_WORKER_TOKEN" \
+  -e CONTROL_PLANE_URL='https://control-plane.example.com' \
+  -e WORKER_QUEUE_ID='8c2e4f61-0b9d-4a37-b5e8-6d1f3a9c2e70' \
+  -e TASK_QUEUE_NAME='production.GPU Workers_v2 (eu) $HOME `id` it'\''s' \
+  -e HEARTBEAT_INTERVAL='30' \
+  'registry.example.com:5000/kubiya/worker:1.4.2'
\ No newline at end of file
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	// defaultWorkerImage is the container image used in the generated worker artifacts
+	defaultWorkerImage = "ghcr.io/kubiyabot/agent-worker:latest"
+	// workerTokenEnvVar is the environment variable workers read the environment worker token from
+	workerTokenEnvVar = "KUBIYA_WORKER_TOKEN"
+)
+
+// workerArtifactParams holds the values the worker deployment artifacts are rendered from
+type workerArtifactParams struct {
+	QueueID           string
+	QueueName         string
+	TaskQueueName     string
+	ControlPlaneURL   string
+	Image             string
+	HeartbeatInterval int64
+	Replicas          int64
+}
+
+// workerArtifacts holds the rendered worker deployment artifacts
+type workerArtifacts struct {
+	DockerRunCommand   string
+	KubernetesManifest string
+	HelmValues         string
+}
+
+var workerArtifactFuncs = template.FuncMap{
+	"quote":      strconv.Quote,
+	"shellQuote": shellQuote,
+	"tokenEnv":   func() string { return workerTokenEnvVar },
+	"secret":     workerTokenSecretName,
+	"k8sName":    workerResourceName,
+}
+
+var dockerRunTemplate = template.Must(template.New("docker").Funcs(workerArtifactFuncs).Parse(
+	`docker run -d --restart unless-stopped --name {{ shellQuote (print (k8sName .QueueName) "-worker") }} \
+  -e {{ tokenEnv }}="${{ tokenEnv }}" \
+  -e CONTROL_PLANE_URL={{ shellQuote .ControlPlaneURL }} \
+  -e WORKER_QUEUE_ID={{ shellQuote .QueueID }} \
+  -e TASK_QUEUE_NAME={{ shellQuote .TaskQueueName }} \
+  -e HEARTBEAT_INTERVAL={{ shellQuote (print .HeartbeatInterval) }} \
+  {{ shellQuote .Image }}`))
+
+var kubernetesManifestTemplate = template.Must(template.New("kubernetes").Funcs(workerArtifactFuncs).Parse(
+	`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ k8sName .QueueName }}-worker
+  labels:
+    app.kubernetes.io/name: kubiya-worker
+    app.kubernetes.io/instance: {{ k8sName .QueueName }}
+    kubiya.ai/worker-queue-id: {{ quote .QueueID }}
+spec:
+  replicas: {{ .Replicas }}
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: kubiya-worker
+      app.kubernetes.io/instance: {{ k8sName .QueueName }}
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: kubiya-worker
+        app.kubernetes.io/instance: {{ k8sName .QueueName }}
+    spec:
+      containers:
+        - name: worker
+          image: {{ quote .Image }}
+          env:
+            - name: {{ tokenEnv }}
+              valueFrom:
+                secretKeyRef:
+                  name: {{ secret .QueueName }}
+                  key: token
+            - name: CONTROL_PLANE_URL
+              value: {{ quote .ControlPlaneURL }}
+            - name: WORKER_QUEUE_ID
+              value: {{ quote .QueueID }}
+            - name: TASK_QUEUE_NAME
+              value: {{ quote .TaskQueueName }}
+            - name: HEARTBEAT_INTERVAL
+              value: {{ quote (print .HeartbeatInterval) }}
+`))
+
+var helmValuesTemplate = template.Must(template.New("helm").Funcs(workerArtifactFuncs).Parse(
+	`image:
+  repository: {{ quote .ImageRepository }}
+  tag: {{ quote .ImageTag }}
+replicaCount: {{ .Replicas }}
+controlPlane:
+  url: {{ quote .ControlPlaneURL }}
+workerQueue:
+  id: {{ quote .QueueID }}
+  name: {{ quote .QueueName }}
+  taskQueueName: {{ quote .TaskQueueName }}
+  heartbeatInterval: {{ .HeartbeatInterval }}
+auth:
+  existingSecret: {{ secret .QueueName }}
+  existingSecretKey: token
+`))
+
+// shellQuote quotes a value as a single POSIX shell word. Nothing expands inside single quotes, so
+// only single quotes themselves need escaping, by closing the quotes around an escaped one.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// workerTokenSecretName returns the name of the Kubernetes secret expected to hold the worker token
+func workerTokenSecretName(queueName string) string {
+	return workerResourceName(queueName) + "-worker-token"
+}
+
+// workerResourceName converts a queue name into a DNS-1123 label, usable in Kubernetes object names,
+// label values and container names: lowercase alphanumerics and dashes, at most 63 characters, starting
+// and ending with an alphanumeric. Other runs of characters become a single dash.
+func workerResourceName(queueName string) string {
+	var name strings.Builder
+	dash := false
+	for _, c := range strings.ToLower(queueName) {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			name.WriteRune(c)
+			dash = false
+		} else if !dash && name.Len() > 0 {
+			name.WriteByte('-')
+			dash = true
+		}
+	}
+
+	label := name.String()
+	if len(label) > 63 {
+		label = label[:63]
+	}
+	label = strings.TrimRight(label, "-")
+	if label == "" {
+		return "worker-queue"
+	}
+
+	return label
+}
+
+// renderWorkerArtifacts renders the docker command, Kubernetes manifest and Helm values for a queue.
+// The worker token is never embedded; it is referenced from the environment or a Kubernetes secret.
+func renderWorkerArtifacts(params workerArtifactParams) (*workerArtifacts, error) {
+	if params.Image == "" {
+		params.Image = defaultWorkerImage
+	}
+
+	if params.Replicas <= 0 {
+		params.Replicas = 1
+	}
+
+	repository, tag := splitImageReference(params.Image)
+	helmParams := struct {
+		workerArtifactParams
+		ImageRepository string
+		ImageTag        string
+	}{params, repository, tag}
+
+	var docker, manifest, values bytes.Buffer
+	if err := dockerRunTemplate.Execute(&docker, params); err != nil {
+		return nil, err
+	}
+
+	if err := kubernetesManifestTemplate.Execute(&manifest, params); err != nil {
+		return nil, err
+	}
+
+	if err := helmValuesTemplate.Execute(&values, helmParams); err != nil {
+		return nil, err
+	}
+
+	return &workerArtifacts{
+		DockerRunCommand:   docker.String(),
+		KubernetesManifest: manifest.String(),
+		HelmValues:         values.String(),
+	}, nil
+}
+
+// splitImageReference splits an image reference into repository and tag, defaulting the tag to latest
+func splitImageReference(image string) (string, string) {
+	lastSlash := strings.LastIndex(image, "/")
+	if idx := strings.LastIndex(image, ":"); idx > lastSlash {
+		return image[:idx], image[idx+1:]
+	}
+
+	return image, "latest"
+}
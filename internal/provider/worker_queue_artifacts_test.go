@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata with the current output")
+
+// assertGolden compares output with the golden file testdata/<name>, rewriting it with -update
+func assertGolden(t *testing.T, name, output string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(output), 0o644))
+	}
+
+	golden, err := os.ReadFile(path)
+	require.NoError(t, err, "run go test with -update to create the golden file")
+	assert.Equal(t, string(golden), output)
+}
+
+var dns1123Label = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+func TestRenderWorkerArtifacts(t *testing.T) {
+	tests := []struct {
+		name   string
+		params workerArtifactParams
+	}{
+		{
+			name: "defaults",
+			params: workerArtifactParams{
+				QueueID:         "3f9a1c52-7d0e-4b8a-9c61-2e5f8d4a7b10",
+				QueueName:       "default",
+				TaskQueueName:   "production.default",
+				ControlPlaneURL: "https://control-plane.kubiya.ai",
+			},
+		},
+		{
+			name: "unsafe_name",
+			params: workerArtifactParams{
+				QueueID:           "8c2e4f61-0b9d-4a37-b5e8-6d1f3a9c2e70",
+				QueueName:         "GPU Workers_v2 (eu)",
+				TaskQueueName:     "production.GPU Workers_v2 (eu) $HOME `id` it's",
+				ControlPlaneURL:   "https://control-plane.example.com",
+				Image:             "registry.example.com:5000/kubiya/worker:1.4.2",
+				HeartbeatInterval: 30,
+				Replicas:          3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifacts, err := renderWorkerArtifacts(tt.params)
+			require.NoError(t, err)
+
+			assertGolden(t, filepath.Join("worker_artifacts", tt.name, "docker.sh"), artifacts.DockerRunCommand)
+			assertGolden(t, filepath.Join("worker_artifacts", tt.name, "kubernetes.yaml"), artifacts.KubernetesManifest)
+			assertGolden(t, filepath.Join("worker_artifacts", tt.name, "helm.yaml"), artifacts.HelmValues)
+
+			// Kubernetes names and labels must be valid whatever the queue name
+			var manifest struct {
+				Metadata struct {
+					Name   string            `yaml:"name"`
+					Labels map[string]string `yaml:"labels"`
+				} `yaml:"metadata"`
+			}
+			require.NoError(t, yaml.Unmarshal([]byte(artifacts.KubernetesManifest), &manifest))
+			assert.Regexp(t, dns1123Label, manifest.Metadata.Name)
+			assert.Regexp(t, dns1123Label, manifest.Metadata.Labels["app.kubernetes.io/instance"])
+
+			var values struct {
+				WorkerQueue struct {
+					Name string `yaml:"name"`
+				} `yaml:"workerQueue"`
+				Auth struct {
+					ExistingSecret string `yaml:"existingSecret"`
+				} `yaml:"auth"`
+			}
+			require.NoError(t, yaml.Unmarshal([]byte(artifacts.HelmValues), &values))
+			assert.Equal(t, tt.params.QueueName, values.WorkerQueue.Name)
+			assert.Regexp(t, dns1123Label, values.Auth.ExistingSecret)
+		})
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	values := []string{
+		"",
+		"production.default",
+		"it's",
+		"''",
+		"$HOME ${PATH} $(id) `id`",
+		`back\slash \n "double"`,
+		"line\nbreak; echo injected && echo *",
+	}
+
+	for _, value := range values {
+		// The shell must print the value back unchanged
+		out, err := exec.Command("sh", "-c", "printf '%s' "+shellQuote(value)).Output()
+		require.NoError(t, err, "value %q", value)
+		assert.Equal(t, value, string(out))
+	}
+}
+
+func TestWorkerResourceName(t *testing.T) {
+	tests := map[string]string{
+		"default":                          "default",
+		"GPU Workers_v2":                   "gpu-workers-v2",
+		"  --Edge.Queue--  ":               "edge-queue",
+		"日本":                               "worker-queue",
+		strings.Repeat("x", 62) + "-y":     strings.Repeat("x", 62),
+		"queue-" + strings.Repeat("x", 70): "queue-" + strings.Repeat("x", 57),
+	}
+
+	for name, want := range tests {
+		got := workerResourceName(name)
+		assert.Equal(t, want, got, "queue name %q", name)
+		assert.Regexp(t, dns1123Label, got)
+	}
+}
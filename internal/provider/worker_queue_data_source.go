@@ -2,18 +2,22 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-kubiya-control-plane/internal/clients"
 )
 
-var _ datasource.DataSource = (*workerQueueDataSource)(nil)
+var (
+	_ datasource.DataSource                     = (*workerQueueDataSource)(nil)
+	_ datasource.DataSourceWithConfigValidators = (*workerQueueDataSource)(nil)
+)
 
 func NewWorkerQueueDataSource() datasource.DataSource {
 	return &workerQueueDataSource{}
@@ -33,7 +37,7 @@ type workerQueueDataSourceModel struct {
 	MaxWorkers        types.Int64  `tfsdk:"max_workers"`
 	HeartbeatInterval types.Int64  `tfsdk:"heartbeat_interval"`
 	Tags              types.List   `tfsdk:"tags"`
-	Settings          types.Map    `tfsdk:"settings"`
+	Settings          types.String `tfsdk:"settings"`
 	CreatedAt         types.String `tfsdk:"created_at"`
 	UpdatedAt         types.String `tfsdk:"updated_at"`
 	ActiveWorkers     types.Int64  `tfsdk:"active_workers"`
@@ -49,15 +53,18 @@ func (d *workerQueueDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 		Description: "Fetches a Worker Queue by ID.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Worker Queue ID",
-				Required:    true,
+				Description: "Worker Queue ID to lookup. Exactly one of id or name must be set",
+				Optional:    true,
+				Computed:    true,
 			},
 			"environment_id": schema.StringAttribute{
-				Description: "Environment ID",
+				Description: "Environment ID. Required when looking up by name, and only set together with it",
+				Optional:    true,
 				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "Worker queue name",
+				Description: "Worker queue name to lookup within environment_id. Exactly one of id or name must be set",
+				Optional:    true,
 				Computed:    true,
 			},
 			"display_name": schema.StringAttribute{
@@ -85,9 +92,8 @@ func (d *workerQueueDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 				ElementType: types.StringType,
 				Computed:    true,
 			},
-			"settings": schema.MapAttribute{
-				Description: "Additional settings as key-value pairs",
-				ElementType: types.StringType,
+			"settings": schema.StringAttribute{
+				Description: "Additional settings as a JSON object string",
 				Computed:    true,
 			},
 			"created_at": schema.StringAttribute{
@@ -110,6 +116,19 @@ func (d *workerQueueDataSource) Schema(_ context.Context, _ datasource.SchemaReq
 	}
 }
 
+func (d *workerQueueDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+		datasourcevalidator.RequiredTogether(
+			path.MatchRoot("name"),
+			path.MatchRoot("environment_id"),
+		),
+	}
+}
+
 func (d *workerQueueDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -135,7 +154,17 @@ func (d *workerQueueDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	queue, err := d.client.GetWorkerQueue(data.ID.ValueString())
+	id := data.ID.ValueString()
+	if data.ID.IsNull() {
+		var err error
+		id, err = lookupWorkerQueueID(ctx, d.client, data.EnvironmentID.ValueString(), data.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading worker queue", err.Error())
+			return
+		}
+	}
+
+	queue, err := d.client.GetWorkerQueue(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading worker queue", err.Error())
 		return
@@ -183,27 +212,12 @@ func (d *workerQueueDataSource) Read(ctx context.Context, req datasource.ReadReq
 	}
 
 	// Convert settings
-	if queue.Settings != nil && len(queue.Settings) > 0 {
-		settingsMap := make(map[string]attr.Value)
-		for k, v := range queue.Settings {
-			// Convert value to JSON string if it's not already a string
-			var strValue string
-			if s, ok := v.(string); ok {
-				strValue = s
-			} else {
-				jsonBytes, err := json.Marshal(v)
-				if err != nil {
-					resp.Diagnostics.AddError("Error marshaling settings value", err.Error())
-					return
-				}
-				strValue = string(jsonBytes)
-			}
-			settingsMap[k] = types.StringValue(strValue)
-		}
-		data.Settings = types.MapValueMust(types.StringType, settingsMap)
-	} else {
-		data.Settings = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	settings, err := toJSONString(queue.Settings)
+	if err != nil {
+		resp.Diagnostics.AddError("Error marshaling settings", err.Error())
+		return
 	}
+	data.Settings = types.StringValue(settings)
 
 	if queue.CreatedAt != nil {
 		data.CreatedAt = types.StringValue(queue.CreatedAt.String())
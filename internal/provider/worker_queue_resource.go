@@ -2,27 +2,38 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-kubiya-control-plane/internal/clients"
 	"terraform-provider-kubiya-control-plane/internal/entities"
+	kubiyasentry "terraform-provider-kubiya-control-plane/internal/sentry"
+)
+
+const (
+	// defaultWorkerQueueDrainTimeout is how long Delete waits for active workers to detach
+	defaultWorkerQueueDrainTimeout = 300
+	// workerQueueDrainPollInterval is how often Delete re-reads the queue while draining
+	workerQueueDrainPollInterval = 5 * time.Second
 )
 
 var _ resource.Resource = (*workerQueueResource)(nil)
 var _ resource.ResourceWithImportState = (*workerQueueResource)(nil)
+var _ resource.ResourceWithUpgradeState = (*workerQueueResource)(nil)
 
 func NewWorkerQueueResource() resource.Resource {
 	return &workerQueueResource{}
@@ -33,20 +44,26 @@ type workerQueueResource struct {
 }
 
 type workerQueueResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	EnvironmentID     types.String `tfsdk:"environment_id"`
-	Name              types.String `tfsdk:"name"`
-	DisplayName       types.String `tfsdk:"display_name"`
-	Description       types.String `tfsdk:"description"`
-	Status            types.String `tfsdk:"status"`
-	MaxWorkers        types.Int64  `tfsdk:"max_workers"`
-	HeartbeatInterval types.Int64  `tfsdk:"heartbeat_interval"`
-	Tags              types.List   `tfsdk:"tags"`
-	Settings          types.Map    `tfsdk:"settings"`
-	CreatedAt         types.String `tfsdk:"created_at"`
-	UpdatedAt         types.String `tfsdk:"updated_at"`
-	ActiveWorkers     types.Int64  `tfsdk:"active_workers"`
-	TaskQueueName     types.String `tfsdk:"task_queue_name"`
+	ID                 types.String `tfsdk:"id"`
+	EnvironmentID      types.String `tfsdk:"environment_id"`
+	Name               types.String `tfsdk:"name"`
+	DisplayName        types.String `tfsdk:"display_name"`
+	Description        types.String `tfsdk:"description"`
+	Status             types.String `tfsdk:"status"`
+	MaxWorkers         types.Int64  `tfsdk:"max_workers"`
+	HeartbeatInterval  types.Int64  `tfsdk:"heartbeat_interval"`
+	Tags               types.List   `tfsdk:"tags"`
+	Settings           types.String `tfsdk:"settings"`
+	CreatedAt          types.String `tfsdk:"created_at"`
+	UpdatedAt          types.String `tfsdk:"updated_at"`
+	ActiveWorkers      types.Int64  `tfsdk:"active_workers"`
+	TaskQueueName      types.String `tfsdk:"task_queue_name"`
+	ForceDestroy       types.Bool   `tfsdk:"force_destroy"`
+	DrainTimeout       types.Int64  `tfsdk:"drain_timeout"`
+	WorkerImage        types.String `tfsdk:"worker_image"`
+	DockerRunCommand   types.String `tfsdk:"docker_run_command"`
+	KubernetesManifest types.String `tfsdk:"kubernetes_manifest"`
+	HelmValues         types.String `tfsdk:"helm_values"`
 }
 
 func (r *workerQueueResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -56,6 +73,7 @@ func (r *workerQueueResource) Metadata(_ context.Context, req resource.MetadataR
 func (r *workerQueueResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Worker Queue in the Control Plane. Worker queues are used to organize and manage workers within an environment.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Worker Queue ID",
@@ -110,12 +128,14 @@ func (r *workerQueueResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Computed:    true,
 				Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
 			},
-			"settings": schema.MapAttribute{
-				Description: "Additional settings as key-value pairs",
-				ElementType: types.StringType,
+			"settings": schema.StringAttribute{
+				Description: "Additional settings as a JSON object string (use jsonencode). Value types are preserved as sent to the API",
 				Optional:    true,
 				Computed:    true,
-				Default:     mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
+				Default:     stringdefault.StaticString("{}"),
+				Validators: []validator.String{
+					workerQueueSettingsValidator{},
+				},
 			},
 			"created_at": schema.StringAttribute{
 				Description: "Creation timestamp",
@@ -133,6 +153,39 @@ func (r *workerQueueResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Description: "Task queue name for Temporal (computed)",
 				Computed:    true,
 			},
+			"force_destroy": schema.BoolAttribute{
+				Description: "Delete the queue immediately on destroy, without pausing it and waiting for active workers to drain",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"drain_timeout": schema.Int64Attribute{
+				Description: "Seconds to wait on destroy for active workers to reach zero after the queue is paused (ignored when force_destroy is true)",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultWorkerQueueDrainTimeout),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"worker_image": schema.StringAttribute{
+				Description: "Container image used in the generated worker deployment artifacts",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultWorkerImage),
+			},
+			"docker_run_command": schema.StringAttribute{
+				Description: "Ready-to-use docker run command that starts a worker for this queue. The worker token is read from the KUBIYA_WORKER_TOKEN environment variable (computed)",
+				Computed:    true,
+			},
+			"kubernetes_manifest": schema.StringAttribute{
+				Description: "Kubernetes Deployment manifest (YAML) for workers of this queue. The worker token is read from the <name>-worker-token secret (computed)",
+				Computed:    true,
+			},
+			"helm_values": schema.StringAttribute{
+				Description: "Helm values document (YAML) for deploying workers of this queue (computed)",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -195,21 +248,15 @@ func (r *workerQueueResource) Create(ctx context.Context, req resource.CreateReq
 
 	// Convert settings
 	if !plan.Settings.IsNull() {
-		var settings map[string]string
-		diags = plan.Settings.ElementsAs(ctx, &settings, false)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
+		settings, err := parseJSON(plan.Settings.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Settings", fmt.Sprintf("Failed to parse settings JSON: %s", err))
 			return
 		}
-		// Convert to map[string]interface{}
-		settingsInterface := make(map[string]interface{})
-		for k, v := range settings {
-			settingsInterface[k] = v
-		}
-		createReq.Settings = settingsInterface
+		createReq.Settings = settings
 	}
 
-	queue, err := r.client.CreateWorkerQueue(plan.EnvironmentID.ValueString(), createReq)
+	queue, err := r.client.CreateWorkerQueue(ctx, plan.EnvironmentID.ValueString(), createReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating worker queue", err.Error())
 		return
@@ -257,27 +304,12 @@ func (r *workerQueueResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	// Convert settings
-	if queue.Settings != nil && len(queue.Settings) > 0 {
-		settingsMap := make(map[string]attr.Value)
-		for k, v := range queue.Settings {
-			// Convert value to JSON string if it's not already a string
-			var strValue string
-			if s, ok := v.(string); ok {
-				strValue = s
-			} else {
-				jsonBytes, err := json.Marshal(v)
-				if err != nil {
-					resp.Diagnostics.AddError("Error marshaling settings value", err.Error())
-					return
-				}
-				strValue = string(jsonBytes)
-			}
-			settingsMap[k] = types.StringValue(strValue)
-		}
-		plan.Settings = types.MapValueMust(types.StringType, settingsMap)
-	} else {
-		plan.Settings = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	settings, err := workerQueueSettingsValue(queue.Settings, plan.Settings)
+	if err != nil {
+		resp.Diagnostics.AddError("Error marshaling settings", err.Error())
+		return
 	}
+	plan.Settings = settings
 
 	if queue.CreatedAt != nil {
 		plan.CreatedAt = types.StringValue(queue.CreatedAt.String())
@@ -294,6 +326,11 @@ func (r *workerQueueResource) Create(ctx context.Context, req resource.CreateReq
 	plan.ActiveWorkers = types.Int64Value(int64(queue.ActiveWorkers))
 	plan.TaskQueueName = types.StringValue(queue.TaskQueueName)
 
+	if err := r.setWorkerArtifacts(&plan); err != nil {
+		resp.Diagnostics.AddError("Error rendering worker deployment artifacts", err.Error())
+		return
+	}
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -306,7 +343,7 @@ func (r *workerQueueResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	queue, err := r.client.GetWorkerQueue(state.ID.ValueString())
+	queue, err := r.client.GetWorkerQueue(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading worker queue", err.Error())
 		return
@@ -354,27 +391,12 @@ func (r *workerQueueResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	// Convert settings
-	if queue.Settings != nil && len(queue.Settings) > 0 {
-		settingsMap := make(map[string]attr.Value)
-		for k, v := range queue.Settings {
-			// Convert value to JSON string if it's not already a string
-			var strValue string
-			if s, ok := v.(string); ok {
-				strValue = s
-			} else {
-				jsonBytes, err := json.Marshal(v)
-				if err != nil {
-					resp.Diagnostics.AddError("Error marshaling settings value", err.Error())
-					return
-				}
-				strValue = string(jsonBytes)
-			}
-			settingsMap[k] = types.StringValue(strValue)
-		}
-		state.Settings = types.MapValueMust(types.StringType, settingsMap)
-	} else {
-		state.Settings = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	settings, err := workerQueueSettingsValue(queue.Settings, state.Settings)
+	if err != nil {
+		resp.Diagnostics.AddError("Error marshaling settings", err.Error())
+		return
 	}
+	state.Settings = settings
 
 	if queue.CreatedAt != nil {
 		state.CreatedAt = types.StringValue(queue.CreatedAt.String())
@@ -391,6 +413,24 @@ func (r *workerQueueResource) Read(ctx context.Context, req resource.ReadRequest
 	state.ActiveWorkers = types.Int64Value(int64(queue.ActiveWorkers))
 	state.TaskQueueName = types.StringValue(queue.TaskQueueName)
 
+	// Destroy behaviour is not stored by the API; fall back to defaults after import
+	if state.ForceDestroy.IsNull() {
+		state.ForceDestroy = types.BoolValue(false)
+	}
+
+	if state.DrainTimeout.IsNull() {
+		state.DrainTimeout = types.Int64Value(defaultWorkerQueueDrainTimeout)
+	}
+
+	if state.WorkerImage.IsNull() {
+		state.WorkerImage = types.StringValue(defaultWorkerImage)
+	}
+
+	if err := r.setWorkerArtifacts(&state); err != nil {
+		resp.Diagnostics.AddError("Error rendering worker deployment artifacts", err.Error())
+		return
+	}
+
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -449,21 +489,20 @@ func (r *workerQueueResource) Update(ctx context.Context, req resource.UpdateReq
 
 	// Convert settings
 	if !plan.Settings.IsNull() {
-		var settings map[string]string
-		diags = plan.Settings.ElementsAs(ctx, &settings, false)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
+		settings, err := parseJSON(plan.Settings.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Settings", fmt.Sprintf("Failed to parse settings JSON: %s", err))
 			return
 		}
-		// Convert to map[string]interface{}
-		settingsInterface := make(map[string]interface{})
-		for k, v := range settings {
-			settingsInterface[k] = v
-		}
-		updateReq.Settings = settingsInterface
+		updateReq.Settings = settings
+	}
+
+	resp.Diagnostics.Append(checkNotModifiedSinceRefresh(ctx, r.client, req.State, "worker queue", (*clients.Client).GetWorkerQueue)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	queue, err := r.client.UpdateWorkerQueue(plan.ID.ValueString(), updateReq)
+	queue, err := r.client.UpdateWorkerQueue(ctx, plan.ID.ValueString(), updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating worker queue", err.Error())
 		return
@@ -510,27 +549,12 @@ func (r *workerQueueResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	// Convert settings
-	if queue.Settings != nil && len(queue.Settings) > 0 {
-		settingsMap := make(map[string]attr.Value)
-		for k, v := range queue.Settings {
-			// Convert value to JSON string if it's not already a string
-			var strValue string
-			if s, ok := v.(string); ok {
-				strValue = s
-			} else {
-				jsonBytes, err := json.Marshal(v)
-				if err != nil {
-					resp.Diagnostics.AddError("Error marshaling settings value", err.Error())
-					return
-				}
-				strValue = string(jsonBytes)
-			}
-			settingsMap[k] = types.StringValue(strValue)
-		}
-		plan.Settings = types.MapValueMust(types.StringType, settingsMap)
-	} else {
-		plan.Settings = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	settings, err := workerQueueSettingsValue(queue.Settings, plan.Settings)
+	if err != nil {
+		resp.Diagnostics.AddError("Error marshaling settings", err.Error())
+		return
 	}
+	plan.Settings = settings
 
 	if queue.UpdatedAt != nil {
 		plan.UpdatedAt = types.StringValue(queue.UpdatedAt.String())
@@ -541,6 +565,11 @@ func (r *workerQueueResource) Update(ctx context.Context, req resource.UpdateReq
 	plan.ActiveWorkers = types.Int64Value(int64(queue.ActiveWorkers))
 	plan.TaskQueueName = types.StringValue(queue.TaskQueueName)
 
+	if err := r.setWorkerArtifacts(&plan); err != nil {
+		resp.Diagnostics.AddError("Error rendering worker deployment artifacts", err.Error())
+		return
+	}
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -553,13 +582,202 @@ func (r *workerQueueResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	err := r.client.DeleteWorkerQueue(state.ID.ValueString())
+	if !state.ForceDestroy.ValueBool() {
+		drainTimeout := time.Duration(state.DrainTimeout.ValueInt64()) * time.Second
+		if err := r.drainWorkerQueue(ctx, state.ID.ValueString(), drainTimeout); err != nil {
+			resp.Diagnostics.AddError("Error draining worker queue", err.Error())
+			return
+		}
+	}
+
+	err := r.client.DeleteWorkerQueue(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting worker queue", err.Error())
 		return
 	}
 }
 
+// setWorkerArtifacts renders the worker deployment artifacts from the queue attributes in the model
+func (r *workerQueueResource) setWorkerArtifacts(model *workerQueueResourceModel) error {
+	artifacts, err := renderWorkerArtifacts(workerArtifactParams{
+		QueueID:           model.ID.ValueString(),
+		QueueName:         model.Name.ValueString(),
+		TaskQueueName:     model.TaskQueueName.ValueString(),
+		ControlPlaneURL:   r.client.BaseURL,
+		Image:             model.WorkerImage.ValueString(),
+		HeartbeatInterval: model.HeartbeatInterval.ValueInt64(),
+		Replicas:          model.MaxWorkers.ValueInt64(),
+	})
+	if err != nil {
+		return err
+	}
+
+	model.DockerRunCommand = types.StringValue(artifacts.DockerRunCommand)
+	model.KubernetesManifest = types.StringValue(artifacts.KubernetesManifest)
+	model.HelmValues = types.StringValue(artifacts.HelmValues)
+
+	return nil
+}
+
+// drainWorkerQueue pauses the queue so no new tasks are dispatched to it, then waits
+// until its active workers reach zero or the timeout expires, whichever comes first.
+func (r *workerQueueResource) drainWorkerQueue(ctx context.Context, queueID string, timeout time.Duration) error {
+	logger := kubiyasentry.LoggerFromContext(ctx)
+
+	// Polling must observe live worker counts, never a cached read
+	client := r.client.Uncached()
+
+	queue, err := client.GetWorkerQueue(ctx, queueID)
+	if err != nil {
+		return err
+	}
+
+	if queue.Status != entities.WorkerQueueStatusPaused {
+		status := string(entities.WorkerQueueStatusPaused)
+		queue, err = client.UpdateWorkerQueue(ctx, queueID, &entities.WorkerQueueUpdateRequest{Status: &status})
+		if err != nil {
+			return fmt.Errorf("failed to pause worker queue: %w", err)
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for queue.ActiveWorkers > 0 {
+		if !time.Now().Before(deadline) {
+			logger.Warn("Worker queue drain timeout expired, deleting with active workers",
+				"queue_id", queueID,
+				"active_workers", queue.ActiveWorkers,
+				"drain_timeout", timeout.String(),
+			)
+			return nil
+		}
+
+		logger.Info("Waiting for worker queue to drain",
+			"queue_id", queueID,
+			"active_workers", queue.ActiveWorkers,
+		)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(workerQueueDrainPollInterval):
+		}
+
+		queue, err = client.GetWorkerQueue(ctx, queueID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportState accepts either the worker queue ID or <environment_name>/<queue_name>
 func (r *workerQueueResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateByLookup(ctx, req, resp, "worker queue", func(importID string) (string, error) {
+		environmentName, queueName, ok := strings.Cut(importID, "/")
+		if !ok || environmentName == "" || queueName == "" {
+			return "", fmt.Errorf("expected a worker queue ID or <environment_name>/<queue_name>")
+		}
+
+		environmentID, err := lookupEnvironmentID(ctx, r.client, environmentName)
+		if err != nil {
+			return "", err
+		}
+
+		return lookupWorkerQueueID(ctx, r.client, environmentID, queueName)
+	})
+}
+
+// workerQueueResourceModelV0 is the state model of schema version 0, where settings was a map(string)
+type workerQueueResourceModelV0 struct {
+	ID                types.String `tfsdk:"id"`
+	EnvironmentID     types.String `tfsdk:"environment_id"`
+	Name              types.String `tfsdk:"name"`
+	DisplayName       types.String `tfsdk:"display_name"`
+	Description       types.String `tfsdk:"description"`
+	Status            types.String `tfsdk:"status"`
+	MaxWorkers        types.Int64  `tfsdk:"max_workers"`
+	HeartbeatInterval types.Int64  `tfsdk:"heartbeat_interval"`
+	Tags              types.List   `tfsdk:"tags"`
+	Settings          types.Map    `tfsdk:"settings"`
+	CreatedAt         types.String `tfsdk:"created_at"`
+	UpdatedAt         types.String `tfsdk:"updated_at"`
+	ActiveWorkers     types.Int64  `tfsdk:"active_workers"`
+	TaskQueueName     types.String `tfsdk:"task_queue_name"`
+}
+
+// workerQueueSchemaV0 is the schema of version 0. Only the attribute types matter to decode prior state.
+var workerQueueSchemaV0 = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"id":                 schema.StringAttribute{Computed: true},
+		"environment_id":     schema.StringAttribute{Required: true},
+		"name":               schema.StringAttribute{Required: true},
+		"display_name":       schema.StringAttribute{Optional: true, Computed: true},
+		"description":        schema.StringAttribute{Optional: true, Computed: true},
+		"status":             schema.StringAttribute{Optional: true, Computed: true},
+		"max_workers":        schema.Int64Attribute{Optional: true},
+		"heartbeat_interval": schema.Int64Attribute{Optional: true, Computed: true},
+		"tags":               schema.ListAttribute{ElementType: types.StringType, Optional: true, Computed: true},
+		"settings":           schema.MapAttribute{ElementType: types.StringType, Optional: true, Computed: true},
+		"created_at":         schema.StringAttribute{Computed: true},
+		"updated_at":         schema.StringAttribute{Computed: true},
+		"active_workers":     schema.Int64Attribute{Computed: true},
+		"task_queue_name":    schema.StringAttribute{Computed: true},
+	},
+}
+
+func (r *workerQueueResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &workerQueueSchemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior workerQueueResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				var settings map[string]string
+				if !prior.Settings.IsNull() && !prior.Settings.IsUnknown() {
+					resp.Diagnostics.Append(prior.Settings.ElementsAs(ctx, &settings, false)...)
+					if resp.Diagnostics.HasError() {
+						return
+					}
+				}
+
+				// Values are kept as strings; the next refresh replaces them with the types the API returns
+				settingsJSON, err := workerQueueSettingsFromStringMap(settings)
+				if err != nil {
+					resp.Diagnostics.AddError("Error upgrading worker queue settings", err.Error())
+					return
+				}
+
+				upgraded := workerQueueResourceModel{
+					ID:                prior.ID,
+					EnvironmentID:     prior.EnvironmentID,
+					Name:              prior.Name,
+					DisplayName:       prior.DisplayName,
+					Description:       prior.Description,
+					Status:            prior.Status,
+					MaxWorkers:        prior.MaxWorkers,
+					HeartbeatInterval: prior.HeartbeatInterval,
+					Tags:              prior.Tags,
+					Settings:          types.StringValue(settingsJSON),
+					CreatedAt:         prior.CreatedAt,
+					UpdatedAt:         prior.UpdatedAt,
+					ActiveWorkers:     prior.ActiveWorkers,
+					TaskQueueName:     prior.TaskQueueName,
+					// Attributes added after version 0 start at their defaults; the next refresh renders the artifacts
+					ForceDestroy:       types.BoolValue(false),
+					DrainTimeout:       types.Int64Value(defaultWorkerQueueDrainTimeout),
+					WorkerImage:        types.StringValue(defaultWorkerImage),
+					DockerRunCommand:   types.StringNull(),
+					KubernetesManifest: types.StringNull(),
+					HelmValues:         types.StringNull(),
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
+		},
+	}
 }
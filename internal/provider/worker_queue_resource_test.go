@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerQueueUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &workerQueueResource{}
+
+	var current resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &current)
+	require.False(t, current.Diagnostics.HasError())
+
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	require.True(t, ok)
+
+	// State written by the provider before settings became a JSON string
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+	str := func(v string) tftypes.Value { return tftypes.NewValue(tftypes.String, v) }
+	num := func(v int64) tftypes.Value { return tftypes.NewValue(tftypes.Number, v) }
+	prior := tftypes.NewValue(priorType, map[string]tftypes.Value{
+		"id":                 str("3f9a1c52-7d0e-4b8a-9c61-2e5f8d4a7b10"),
+		"environment_id":     str("0b6e2d94-5c1a-4f38-8e27-9d4c3b1a6f05"),
+		"name":               str("default"),
+		"display_name":       str("Default"),
+		"description":        str(""),
+		"status":             str("active"),
+		"max_workers":        tftypes.NewValue(tftypes.Number, nil),
+		"heartbeat_interval": num(60),
+		"tags":               tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{str("gpu")}),
+		"settings": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"region":     str("eu-west-1"),
+			"autoscale":  str("true"),
+			"batch_size": str("10"),
+		}),
+		"created_at":      str("2025-01-10 09:00:00 +0000 UTC"),
+		"updated_at":      str("2025-01-11 09:00:00 +0000 UTC"),
+		"active_workers":  num(2),
+		"task_queue_name": str("production.default"),
+	})
+
+	req := resource.UpgradeStateRequest{State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: prior}}
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{
+		Schema: current.Schema,
+		Raw:    tftypes.NewValue(current.Schema.Type().TerraformType(ctx), nil),
+	}}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var upgraded workerQueueResourceModel
+	require.False(t, resp.State.Get(ctx, &upgraded).HasError())
+
+	// Values stay strings until the next refresh returns their API types
+	assert.JSONEq(t, `{"autoscale":"true","batch_size":"10","region":"eu-west-1"}`, upgraded.Settings.ValueString())
+	assert.Equal(t, "default", upgraded.Name.ValueString())
+	assert.Equal(t, "Default", upgraded.DisplayName.ValueString())
+	assert.True(t, upgraded.MaxWorkers.IsNull())
+	assert.Equal(t, int64(2), upgraded.ActiveWorkers.ValueInt64())
+	assert.Equal(t, "production.default", upgraded.TaskQueueName.ValueString())
+
+	// Attributes added after version 0 start at their defaults
+	assert.False(t, upgraded.ForceDestroy.ValueBool())
+	assert.Equal(t, int64(defaultWorkerQueueDrainTimeout), upgraded.DrainTimeout.ValueInt64())
+	assert.Equal(t, defaultWorkerImage, upgraded.WorkerImage.ValueString())
+	assert.True(t, upgraded.DockerRunCommand.IsNull())
+}
+
+func TestWorkerQueueDrainTimeoutValidation(t *testing.T) {
+	ctx := context.Background()
+	r := &workerQueueResource{}
+
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+	attribute := resp.Schema.Attributes["drain_timeout"].(schema.Int64Attribute)
+
+	for value, valid := range map[int64]bool{-1: false, 0: true, 60: true} {
+		var diags diag.Diagnostics
+		for _, v := range attribute.Validators {
+			validateResp := validator.Int64Response{}
+			v.ValidateInt64(ctx, validator.Int64Request{Path: path.Root("drain_timeout"), ConfigValue: types.Int64Value(value)}, &validateResp)
+			diags.Append(validateResp.Diagnostics...)
+		}
+		assert.Equal(t, !valid, diags.HasError(), "drain_timeout = %d", value)
+	}
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// workerQueueAutoscalingKeys lists the keys accepted in the "autoscaling" settings object
+var workerQueueAutoscalingKeys = map[string]string{
+	"enabled":              "bool",
+	"min_workers":          "integer",
+	"max_workers":          "integer",
+	"scale_up_threshold":   "number",
+	"scale_down_threshold": "number",
+	"cooldown_seconds":     "integer",
+}
+
+var _ validator.String = workerQueueSettingsValidator{}
+
+// workerQueueSettingsValidator checks that settings is a JSON object and that known keys are well-formed
+type workerQueueSettingsValidator struct{}
+
+func (v workerQueueSettingsValidator) Description(_ context.Context) string {
+	return "settings must be a JSON object; the autoscaling key, when set, must hold valid thresholds"
+}
+
+func (v workerQueueSettingsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v workerQueueSettingsValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	settings, err := parseJSON(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Settings", fmt.Sprintf("settings must be a JSON object: %s", err))
+		return
+	}
+
+	raw, ok := settings["autoscaling"]
+	if !ok {
+		return
+	}
+
+	for _, problem := range validateWorkerQueueAutoscaling(raw) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Autoscaling Settings", problem)
+	}
+}
+
+// validateWorkerQueueAutoscaling returns a description of every problem found in the autoscaling settings
+func validateWorkerQueueAutoscaling(raw interface{}) []string {
+	autoscaling, ok := raw.(map[string]interface{})
+	if !ok {
+		return []string{"autoscaling must be a JSON object"}
+	}
+
+	var problems []string
+	for key, value := range autoscaling {
+		kind, known := workerQueueAutoscalingKeys[key]
+		if !known {
+			problems = append(problems, fmt.Sprintf("autoscaling.%s is not a supported key", key))
+			continue
+		}
+
+		switch kind {
+		case "bool":
+			if _, ok := value.(bool); !ok {
+				problems = append(problems, fmt.Sprintf("autoscaling.%s must be a boolean", key))
+			}
+		case "integer":
+			n, ok := value.(float64)
+			if !ok || n != math.Trunc(n) || n < 0 {
+				problems = append(problems, fmt.Sprintf("autoscaling.%s must be a non-negative integer", key))
+			}
+		case "number":
+			n, ok := value.(float64)
+			if !ok || n < 0 || n > 100 {
+				problems = append(problems, fmt.Sprintf("autoscaling.%s must be a number between 0 and 100", key))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return problems
+	}
+
+	minWorkers, hasMin := autoscaling["min_workers"].(float64)
+	maxWorkers, hasMax := autoscaling["max_workers"].(float64)
+	if hasMin && hasMax && minWorkers > maxWorkers {
+		problems = append(problems, "autoscaling.min_workers must not be greater than autoscaling.max_workers")
+	}
+
+	scaleUp, hasUp := autoscaling["scale_up_threshold"].(float64)
+	scaleDown, hasDown := autoscaling["scale_down_threshold"].(float64)
+	if hasUp && hasDown && scaleDown >= scaleUp {
+		problems = append(problems, "autoscaling.scale_down_threshold must be lower than autoscaling.scale_up_threshold")
+	}
+
+	return problems
+}
+
+// workerQueueSettingsValue converts API settings to a JSON string, keeping the prior string when it
+// is semantically equal so that formatting and key order never show up as drift
+func workerQueueSettingsValue(settings map[string]interface{}, prior types.String) (types.String, error) {
+	current, err := toJSONString(settings)
+	if err != nil {
+		return types.StringNull(), err
+	}
+
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if priorSettings, err := parseJSON(prior.ValueString()); err == nil {
+			if normalized, err := toJSONString(priorSettings); err == nil && normalized == current {
+				return prior, nil
+			}
+		}
+	}
+
+	return types.StringValue(current), nil
+}
+
+// workerQueueSettingsFromStringMap converts the map(string) settings of schema version 0 to a JSON string
+func workerQueueSettingsFromStringMap(settings map[string]string) (string, error) {
+	converted := make(map[string]interface{}, len(settings))
+	for k, v := range settings {
+		converted[k] = v
+	}
+
+	return toJSONString(converted)
+}
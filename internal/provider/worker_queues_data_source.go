@@ -2,7 +2,6 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -82,9 +81,8 @@ func (d *workerQueuesDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 							ElementType: types.StringType,
 							Computed:    true,
 						},
-						"settings": schema.MapAttribute{
-							Description: "Additional settings as key-value pairs",
-							ElementType: types.StringType,
+						"settings": schema.StringAttribute{
+							Description: "Additional settings as a JSON object string",
 							Computed:    true,
 						},
 						"created_at": schema.StringAttribute{
@@ -135,14 +133,13 @@ func (d *workerQueuesDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	queues, err := d.client.ListWorkerQueues(data.EnvironmentID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error listing worker queues", err.Error())
-		return
-	}
+	data.Queues = []workerQueueDataSourceModel{}
+	for queue, err := range d.client.IterWorkerQueues(ctx, data.EnvironmentID.ValueString(), nil) {
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing worker queues", err.Error())
+			return
+		}
 
-	data.Queues = make([]workerQueueDataSourceModel, 0, len(queues))
-	for _, queue := range queues {
 		queueModel := workerQueueDataSourceModel{
 			ID:                types.StringValue(queue.ID),
 			EnvironmentID:     types.StringValue(queue.EnvironmentID),
@@ -188,27 +185,12 @@ func (d *workerQueuesDataSource) Read(ctx context.Context, req datasource.ReadRe
 		}
 
 		// Convert settings
-		if queue.Settings != nil && len(queue.Settings) > 0 {
-			settingsMap := make(map[string]attr.Value)
-			for k, v := range queue.Settings {
-				// Convert value to JSON string if it's not already a string
-				var strValue string
-				if s, ok := v.(string); ok {
-					strValue = s
-				} else {
-					jsonBytes, err := json.Marshal(v)
-					if err != nil {
-						resp.Diagnostics.AddError("Error marshaling settings value", err.Error())
-						return
-					}
-					strValue = string(jsonBytes)
-				}
-				settingsMap[k] = types.StringValue(strValue)
-			}
-			queueModel.Settings = types.MapValueMust(types.StringType, settingsMap)
-		} else {
-			queueModel.Settings = types.MapValueMust(types.StringType, map[string]attr.Value{})
+		settings, err := toJSONString(queue.Settings)
+		if err != nil {
+			resp.Diagnostics.AddError("Error marshaling settings", err.Error())
+			return
 		}
+		queueModel.Settings = types.StringValue(settings)
 
 		if queue.CreatedAt != nil {
 			queueModel.CreatedAt = types.StringValue(queue.CreatedAt.String())
@@ -3,18 +3,30 @@ package main
 import (
 	"context"
 	"log"
+	"os"
+	"os/signal"
 
+	"terraform-provider-kubiya-control-plane/internal/generate"
 	"terraform-provider-kubiya-control-plane/internal/provider"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
-const (
-	version = "dev"
-	address = "hashicorp.com/kubiya/control-plane"
-)
+// version is set by goreleaser with -X main.version, which only works on a variable
+var version = "dev"
+
+const address = "hashicorp.com/kubiya/control-plane"
 
 func main() {
+	// "generate" renders configuration for existing objects instead of serving the plugin
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		// Interrupting stops the requests in flight instead of waiting for them
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		code := generate.Run(ctx, os.Args[2:], os.Stderr)
+		stop()
+		os.Exit(code)
+	}
+
 	ctx := context.Background()
 	kubiyaProvider := provider.New(version)
 
@@ -160,7 +160,7 @@ resource "controlplane_worker_queue" "this" {
   max_workers        = each.value.max_workers
   tags               = each.value.tags
 
-  settings = each.value.settings
+  settings = jsonencode(each.value.settings)
 
   depends_on = [controlplane_environment.this]
 }
@@ -0,0 +1,53 @@
+package controlplane
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// CreateAgent creates a new agent
+func (c *Client) CreateAgent(ctx context.Context, req *AgentCreateRequest) (*Agent, error) {
+	var agent Agent
+	if err := c.Do(ctx, http.MethodPost, "/api/v1/agents", req, &agent); err != nil {
+		return nil, err
+	}
+
+	return &agent, nil
+}
+
+// GetAgent retrieves an agent by ID
+func (c *Client) GetAgent(ctx context.Context, id string) (*Agent, error) {
+	var agent Agent
+	if err := c.Do(ctx, http.MethodGet, "/api/v1/agents/"+url.PathEscape(id), nil, &agent); err != nil {
+		return nil, err
+	}
+
+	return &agent, nil
+}
+
+// UpdateAgent updates an existing agent
+func (c *Client) UpdateAgent(ctx context.Context, id string, req *AgentUpdateRequest) (*Agent, error) {
+	var agent Agent
+	if err := c.Do(ctx, http.MethodPatch, "/api/v1/agents/"+url.PathEscape(id), req, &agent); err != nil {
+		return nil, err
+	}
+
+	return &agent, nil
+}
+
+// DeleteAgent deletes an agent
+func (c *Client) DeleteAgent(ctx context.Context, id string) error {
+	return c.Do(ctx, http.MethodDelete, "/api/v1/agents/"+url.PathEscape(id), nil, nil)
+}
+
+// ListAgents lists all agents
+func (c *Client) ListAgents(ctx context.Context, opts *ListOptions) ([]*Agent, error) {
+	return collect(c.IterAgents(ctx, opts))
+}
+
+// IterAgents iterates over all agents, fetching them page by page
+func (c *Client) IterAgents(ctx context.Context, opts *ListOptions) iter.Seq2[*Agent, error] {
+	return paginate[*Agent](ctx, c, "/api/v1/agents", opts)
+}
@@ -0,0 +1,304 @@
+// Package controlplane is a Go client for the Kubiya Control Plane API.
+//
+// It covers agents, teams, projects, environments, worker queues, skills, policies, policy
+// associations and jobs:
+//
+//	client, err := controlplane.New(
+//		controlplane.WithAPIKey(os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")),
+//		controlplane.WithRetries(3),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//
+//	agent, err := client.GetAgent(ctx, id)
+//	if errors.Is(err, controlplane.ErrNotFound) {
+//		// the agent was deleted
+//	}
+//
+// List endpoints are paged transparently. IterAgents and friends fetch one page at a time, while
+// ListAgents and friends collect every page into a slice.
+//
+// The package follows semantic versioning together with the Terraform provider it ships in; see
+// Version.
+package controlplane
+
+import (
+	"bytes"
+	"context"
+	crand "crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is the version of this SDK, sent in the default User-Agent
+const Version = "0.1.0"
+
+// DefaultBaseURL is the production Control Plane API
+const DefaultBaseURL = "https://control-plane.kubiya.ai"
+
+// RequestIDHeader carries the ID the client generates for every call. The API answers with its own
+// request ID in the same header.
+const RequestIDHeader = "X-Request-ID"
+
+const (
+	// defaultRetries is how many times a failed request is retried unless WithRetries says otherwise
+	defaultRetries = 3
+	// defaultRetryWait is the first backoff between retries; it doubles with every attempt
+	defaultRetryWait = 500 * time.Millisecond
+	// maxRetryWait caps a single backoff, including one requested by the server
+	maxRetryWait = time.Minute
+)
+
+// Client talks to the Control Plane API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	userAgent  string
+	maxRetries int
+	retryWait  time.Duration
+	pageSize   int
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithAPIKey sets the API key sent as a bearer token
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithBaseURL sets the API base URL. Default: DefaultBaseURL
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests, e.g. to add a custom transport or
+// timeout. Default: a client with a 60 second timeout.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithUserAgent sets the User-Agent header. Default: kubiya-control-plane-go/<Version>
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRetries sets how many times a request is retried after a 429 response, and, for idempotent
+// methods, after a 5xx response or a network error. Zero disables retries. Default: 3
+func WithRetries(maxRetries int) Option {
+	return func(c *Client) {
+		c.maxRetries = max(maxRetries, 0)
+	}
+}
+
+// WithRetryWait sets the first backoff between retries; it doubles with every attempt. A
+// Retry-After header sent by the server takes precedence. Default: 500ms
+func WithRetryWait(wait time.Duration) Option {
+	return func(c *Client) {
+		c.retryWait = wait
+	}
+}
+
+// WithPageSize sets the number of items requested per page by list calls. Default: DefaultPageSize
+func WithPageSize(pageSize int) Option {
+	return func(c *Client) {
+		if pageSize > 0 {
+			c.pageSize = min(pageSize, MaxPageSize)
+		}
+	}
+}
+
+// New creates a client. An API key is required.
+func New(opts ...Option) (*Client, error) {
+	c := &Client{
+		baseURL:    DefaultBaseURL,
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+		userAgent:  "kubiya-control-plane-go/" + Version,
+		maxRetries: defaultRetries,
+		retryWait:  defaultRetryWait,
+		pageSize:   DefaultPageSize,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("API key is required")
+	}
+
+	if c.httpClient == nil {
+		return nil, fmt.Errorf("HTTP client must not be nil")
+	}
+
+	return c, nil
+}
+
+// BaseURL returns the API base URL the client sends requests to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// Do sends a request to path, relative to the base URL, and decodes a successful JSON response into
+// out unless it is nil. body, when not nil, is sent as JSON. A response outside the 2xx range is
+// returned as an *APIError.
+func (c *Client) Do(ctx context.Context, method, path string, body, out interface{}) error {
+	_, err := c.do(ctx, method, path, body, out)
+	return err
+}
+
+// do is Do, and also returns the request ID it sent
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) (string, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal request body: %w", err)
+		}
+	}
+
+	// One ID identifies the call across retries, so that it can be quoted in support tickets
+	requestID := newRequestID()
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, path, payload, requestID)
+		if err != nil {
+			if ctx.Err() != nil || attempt >= c.maxRetries || !idempotent(method) {
+				return requestID, &RequestError{Method: method, Path: path, RequestID: requestID, Err: err}
+			}
+			if err := c.wait(ctx, c.backoff(attempt)); err != nil {
+				return requestID, err
+			}
+			continue
+		}
+
+		data, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return requestID, &RequestError{Method: method, Path: path, RequestID: requestID, Err: fmt.Errorf("failed to read response body: %w", err)}
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			if out != nil && len(data) > 0 {
+				if err := json.Unmarshal(data, out); err != nil {
+					return requestID, &RequestError{Method: method, Path: path, RequestID: requestID, Err: fmt.Errorf("failed to parse response: %w", err)}
+				}
+			}
+			return requestID, nil
+		}
+
+		apiErr := newAPIError(method, path, requestID, resp, data)
+		if attempt >= c.maxRetries || !retryable(method, resp.StatusCode) {
+			return requestID, apiErr
+		}
+
+		delay, ok := retryAfter(resp)
+		if !ok {
+			delay = c.backoff(attempt)
+		}
+		if err := c.wait(ctx, delay); err != nil {
+			return requestID, err
+		}
+	}
+}
+
+// send performs a single HTTP request
+func (c *Client) send(ctx context.Context, method, path string, payload []byte, requestID string) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set(RequestIDHeader, requestID)
+
+	return c.httpClient.Do(req)
+}
+
+// newRequestID returns a random UUID v4 for the X-Request-ID header
+func newRequestID() string {
+	var b [16]byte
+	_, _ = crand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// backoff returns the exponential backoff with jitter before the given retry
+func (c *Client) backoff(attempt int) time.Duration {
+	wait := min(c.retryWait<<attempt, maxRetryWait)
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// wait sleeps for d unless ctx is done first
+func (c *Client) wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// idempotent reports whether a request with the method can safely be sent twice
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+
+	return false
+}
+
+// retryable reports whether a response with the status code is worth retrying
+func retryable(method string, statusCode int) bool {
+	if statusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return statusCode >= 500 && idempotent(method)
+}
+
+// retryAfter returns the wait requested by the Retry-After header, if any
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return min(time.Duration(seconds)*time.Second, maxRetryWait), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return min(max(time.Until(date), 0), maxRetryWait), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,210 @@
+package controlplane_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/pkg/controlplane"
+	"terraform-provider-kubiya-control-plane/pkg/fakeserver"
+)
+
+func newClient(t *testing.T, opts ...controlplane.Option) (*fakeserver.Server, *controlplane.Client) {
+	t.Helper()
+
+	server := fakeserver.New()
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	opts = append([]controlplane.Option{
+		controlplane.WithAPIKey("test-api-key"),
+		controlplane.WithBaseURL(httpServer.URL),
+		controlplane.WithRetryWait(time.Millisecond),
+	}, opts...)
+
+	client, err := controlplane.New(opts...)
+	require.NoError(t, err)
+
+	return server, client
+}
+
+func TestNewRequiresAPIKey(t *testing.T) {
+	_, err := controlplane.New()
+	require.Error(t, err)
+}
+
+func TestTeamLifecycle(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	team, err := client.CreateTeam(ctx, &controlplane.TeamCreateRequest{Name: "platform"})
+	require.NoError(t, err)
+
+	description := "Platform team"
+	updated, err := client.UpdateTeam(ctx, team.ID, &controlplane.TeamUpdateRequest{Description: &description})
+	require.NoError(t, err)
+	assert.Equal(t, description, *updated.Description)
+
+	teams, err := client.ListTeams(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, teams, 1)
+
+	require.NoError(t, client.DeleteTeam(ctx, team.ID))
+
+	_, err = client.GetTeam(ctx, team.ID)
+	assert.True(t, controlplane.IsNotFound(err))
+
+	var apiErr *controlplane.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "Team not found", apiErr.Detail)
+}
+
+func TestTypedErrors(t *testing.T) {
+	_, client := newClient(t)
+	ctx := context.Background()
+
+	_, err := client.CreateProject(ctx, &controlplane.ProjectCreateRequest{Name: "one"})
+	assert.ErrorIs(t, err, controlplane.ErrBadRequest)
+
+	_, err = client.CreateProject(ctx, &controlplane.ProjectCreateRequest{Name: "one", Key: "ONE"})
+	require.NoError(t, err)
+	_, err = client.CreateProject(ctx, &controlplane.ProjectCreateRequest{Name: "two", Key: "ONE"})
+	assert.ErrorIs(t, err, controlplane.ErrConflict)
+}
+
+func TestRetries(t *testing.T) {
+	server, client := newClient(t, controlplane.WithRetries(2))
+	ctx := context.Background()
+
+	server.InjectFault(fakeserver.Fault{Method: http.MethodGet, StatusCode: http.StatusInternalServerError, Times: 2})
+	_, err := client.ListAgents(ctx, nil)
+	require.NoError(t, err, "idempotent requests are retried after 5xx responses")
+
+	server.InjectFault(fakeserver.Fault{Method: http.MethodPost, StatusCode: http.StatusInternalServerError, Times: 1})
+	_, err = client.CreateAgent(ctx, &controlplane.AgentCreateRequest{Name: "helper"})
+	assert.ErrorIs(t, err, controlplane.ErrServer, "POST is not retried after a 5xx response")
+
+	server.InjectFault(fakeserver.Fault{Method: http.MethodPost, StatusCode: http.StatusTooManyRequests, Times: 2})
+	_, err = client.CreateAgent(ctx, &controlplane.AgentCreateRequest{Name: "helper"})
+	require.NoError(t, err, "429 responses are retried for every method")
+
+	server.InjectFault(fakeserver.Fault{StatusCode: http.StatusTooManyRequests, Times: 3})
+	_, err = client.ListAgents(ctx, nil)
+	assert.ErrorIs(t, err, controlplane.ErrRateLimited)
+}
+
+func TestContextCancellation(t *testing.T) {
+	server, client := newClient(t)
+	server.InjectFault(fakeserver.Fault{Latency: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.ListAgents(ctx, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPagination(t *testing.T) {
+	_, client := newClient(t, controlplane.WithPageSize(10))
+	ctx := context.Background()
+
+	for i := 0; i < 25; i++ {
+		_, err := client.CreateSkill(ctx, &controlplane.SkillCreateRequest{Name: "skill", Type: controlplane.SkillTypeShell, Enabled: true})
+		require.NoError(t, err)
+	}
+
+	count := 0
+	for skill, err := range client.IterSkills(ctx, nil) {
+		require.NoError(t, err)
+		assert.Equal(t, controlplane.SkillTypeShell, skill.Type)
+		count++
+	}
+	assert.Equal(t, 25, count)
+
+	// Stopping early does not fetch the remaining pages
+	count = 0
+	for range client.IterSkills(ctx, nil) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, count)
+}
+
+func TestRequestIDs(t *testing.T) {
+	_, client := newClient(t)
+
+	_, err := client.GetAgent(context.Background(), "missing")
+
+	var apiErr *controlplane.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.NotEmpty(t, apiErr.RequestID)
+	assert.NotEmpty(t, apiErr.ServerRequestID)
+	assert.NotEqual(t, apiErr.RequestID, apiErr.ServerRequestID)
+	assert.Contains(t, err.Error(), "request ID: "+apiErr.RequestID)
+	assert.Contains(t, err.Error(), "server request ID: "+apiErr.ServerRequestID)
+}
+
+func TestParseErrorRequestID(t *testing.T) {
+	var requestID string
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = r.Header.Get(controlplane.RequestIDHeader)
+		if r.URL.Query().Has("limit") {
+			_, _ = w.Write([]byte(`["not an agent"]`))
+			return
+		}
+		_, _ = w.Write([]byte("<html>gateway</html>"))
+	}))
+	defer httpServer.Close()
+
+	client, err := controlplane.New(controlplane.WithAPIKey("test-api-key"), controlplane.WithBaseURL(httpServer.URL))
+	require.NoError(t, err)
+
+	_, err = client.GetAgent(context.Background(), "a1")
+
+	var reqErr *controlplane.RequestError
+	require.True(t, errors.As(err, &reqErr))
+	assert.Equal(t, requestID, reqErr.RequestID)
+	assert.Contains(t, err.Error(), "failed to parse response")
+	assert.Contains(t, err.Error(), "request ID: "+requestID)
+
+	var syntaxErr *json.SyntaxError
+	assert.True(t, errors.As(err, &syntaxErr))
+
+	// An item of a page that cannot be parsed carries the ID of the page request
+	_, err = client.ListAgents(context.Background(), nil)
+	require.True(t, errors.As(err, &reqErr))
+	assert.Equal(t, requestID, reqErr.RequestID)
+	assert.Contains(t, err.Error(), "failed to parse response")
+}
+
+func TestHeaders(t *testing.T) {
+	var userAgent, requestID string
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		requestID = r.Header.Get(controlplane.RequestIDHeader)
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer httpServer.Close()
+
+	client, err := controlplane.New(
+		controlplane.WithAPIKey("test-api-key"),
+		controlplane.WithBaseURL(httpServer.URL),
+		controlplane.WithUserAgent("migration-bot/1.0"),
+	)
+	require.NoError(t, err)
+
+	_, err = client.ListAgents(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "migration-bot/1.0", userAgent)
+	assert.Len(t, requestID, 36)
+}
@@ -0,0 +1,53 @@
+package controlplane
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// CreateEnvironment creates a new environment
+func (c *Client) CreateEnvironment(ctx context.Context, req *EnvironmentCreateRequest) (*Environment, error) {
+	var environment Environment
+	if err := c.Do(ctx, http.MethodPost, "/api/v1/environments", req, &environment); err != nil {
+		return nil, err
+	}
+
+	return &environment, nil
+}
+
+// GetEnvironment retrieves an environment by ID
+func (c *Client) GetEnvironment(ctx context.Context, id string) (*Environment, error) {
+	var environment Environment
+	if err := c.Do(ctx, http.MethodGet, "/api/v1/environments/"+url.PathEscape(id), nil, &environment); err != nil {
+		return nil, err
+	}
+
+	return &environment, nil
+}
+
+// UpdateEnvironment updates an existing environment
+func (c *Client) UpdateEnvironment(ctx context.Context, id string, req *EnvironmentUpdateRequest) (*Environment, error) {
+	var environment Environment
+	if err := c.Do(ctx, http.MethodPatch, "/api/v1/environments/"+url.PathEscape(id), req, &environment); err != nil {
+		return nil, err
+	}
+
+	return &environment, nil
+}
+
+// DeleteEnvironment deletes an environment
+func (c *Client) DeleteEnvironment(ctx context.Context, id string) error {
+	return c.Do(ctx, http.MethodDelete, "/api/v1/environments/"+url.PathEscape(id), nil, nil)
+}
+
+// ListEnvironments lists all environments
+func (c *Client) ListEnvironments(ctx context.Context, opts *ListOptions) ([]*Environment, error) {
+	return collect(c.IterEnvironments(ctx, opts))
+}
+
+// IterEnvironments iterates over all environments, fetching them page by page
+func (c *Client) IterEnvironments(ctx context.Context, opts *ListOptions) iter.Seq2[*Environment, error] {
+	return paginate[*Environment](ctx, c, "/api/v1/environments", opts)
+}
@@ -0,0 +1,132 @@
+package controlplane
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors matched by *APIError with errors.Is
+var (
+	// ErrBadRequest matches 400 and 422 responses, i.e. requests the API rejected as invalid
+	ErrBadRequest = errors.New("bad request")
+	// ErrUnauthorized matches 401 and 403 responses
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrNotFound matches 404 responses
+	ErrNotFound = errors.New("not found")
+	// ErrConflict matches 409 responses
+	ErrConflict = errors.New("conflict")
+	// ErrRateLimited matches 429 responses
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServer matches 5xx responses
+	ErrServer = errors.New("server error")
+)
+
+// APIError is a response from the API outside the 2xx range
+type APIError struct {
+	// Method and Path identify the request that failed
+	Method string
+	Path   string
+	// RequestID is the X-Request-ID the client sent
+	RequestID string
+	// ServerRequestID is the request ID the API reported in its response, if any
+	ServerRequestID string
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// Detail is the error message the API returned, if it could be extracted from the body
+	Detail string
+	// Body is the raw response body
+	Body []byte
+	// Header holds the response headers
+	Header http.Header
+}
+
+func newAPIError(method, path, requestID string, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		Method:          method,
+		Path:            path,
+		RequestID:       requestID,
+		ServerRequestID: serverRequestID(resp.Header),
+		StatusCode:      resp.StatusCode,
+		Body:            body,
+		Header:          resp.Header,
+	}
+
+	// The API reports errors as {"detail": "..."}; validation errors carry a list instead
+	var payload struct {
+		Detail json.RawMessage `json:"detail"`
+	}
+	if json.Unmarshal(body, &payload) == nil && len(payload.Detail) > 0 {
+		var detail string
+		if json.Unmarshal(payload.Detail, &detail) == nil {
+			apiErr.Detail = detail
+		} else {
+			apiErr.Detail = string(payload.Detail)
+		}
+	}
+
+	return apiErr
+}
+
+// Error keeps the raw body in the message, since it is often the only clue to what went wrong, and
+// ends with the request IDs to quote in support tickets
+func (e *APIError) Error() string {
+	ids := "request ID: " + e.RequestID
+	if e.ServerRequestID != "" && e.ServerRequestID != e.RequestID {
+		ids += ", server request ID: " + e.ServerRequestID
+	}
+
+	return fmt.Sprintf("API error (status %d): %s (%s)", e.StatusCode, string(e.Body), ids)
+}
+
+// Is matches the sentinel error for the status code
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+
+	return false
+}
+
+// RequestError is a request that failed without an error response from the API, e.g. because of a
+// network error or a response body that could not be parsed
+type RequestError struct {
+	Method    string
+	Path      string
+	RequestID string
+	Err       error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("request failed: %v (request ID: %s)", e.Err, e.RequestID)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// serverRequestID returns the request ID the API reported in a response
+func serverRequestID(header http.Header) string {
+	if id := header.Get(RequestIDHeader); id != "" {
+		return id
+	}
+
+	return header.Get("X-Correlation-ID")
+}
+
+// IsNotFound reports whether err is a 404 response from the API
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
@@ -0,0 +1,73 @@
+package controlplane
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// CreateJob creates a new job
+func (c *Client) CreateJob(ctx context.Context, req *JobCreateRequest) (*Job, error) {
+	var job Job
+	if err := c.Do(ctx, http.MethodPost, "/api/v1/jobs", req, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// GetJob retrieves a job by ID
+func (c *Client) GetJob(ctx context.Context, id string) (*Job, error) {
+	var job Job
+	if err := c.Do(ctx, http.MethodGet, "/api/v1/jobs/"+url.PathEscape(id), nil, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// UpdateJob updates an existing job
+func (c *Client) UpdateJob(ctx context.Context, id string, req *JobUpdateRequest) (*Job, error) {
+	var job Job
+	if err := c.Do(ctx, http.MethodPatch, "/api/v1/jobs/"+url.PathEscape(id), req, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// DeleteJob deletes a job
+func (c *Client) DeleteJob(ctx context.Context, id string) error {
+	return c.Do(ctx, http.MethodDelete, "/api/v1/jobs/"+url.PathEscape(id), nil, nil)
+}
+
+// ListJobs lists all jobs
+func (c *Client) ListJobs(ctx context.Context, opts *ListOptions) ([]*Job, error) {
+	return collect(c.IterJobs(ctx, opts))
+}
+
+// IterJobs iterates over all jobs, fetching them page by page
+func (c *Client) IterJobs(ctx context.Context, opts *ListOptions) iter.Seq2[*Job, error] {
+	return paginate[*Job](ctx, c, "/api/v1/jobs", opts)
+}
+
+// EnableJob enables a job
+func (c *Client) EnableJob(ctx context.Context, id string) (*Job, error) {
+	var job Job
+	if err := c.Do(ctx, http.MethodPost, "/api/v1/jobs/"+url.PathEscape(id)+"/enable", nil, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// DisableJob disables a job
+func (c *Client) DisableJob(ctx context.Context, id string) (*Job, error) {
+	var job Job
+	if err := c.Do(ctx, http.MethodPost, "/api/v1/jobs/"+url.PathEscape(id)+"/disable", nil, &job); err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
@@ -0,0 +1,106 @@
+package controlplane
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the number of items requested per page when listing objects
+const DefaultPageSize = 100
+
+// MaxPageSize is the largest page size the API accepts
+const MaxPageSize = 1000
+
+// ListOptions controls paging and server-side filtering of list requests
+type ListOptions struct {
+	// PageSize is the number of items requested per page; zero uses the client page size
+	PageSize int
+	// Filters are sent as query parameters; empty values are omitted
+	Filters map[string]string
+}
+
+// pageSizeFor returns the page size to use for a list request
+func (c *Client) pageSizeFor(opts *ListOptions) int {
+	if opts != nil && opts.PageSize > 0 {
+		return min(opts.PageSize, MaxPageSize)
+	}
+
+	return c.pageSize
+}
+
+// paginate returns an iterator over every item of a list endpoint, fetching one page at a time with
+// skip/limit query parameters. Iteration stops at the first short page or at the first error, which
+// is yielded with a zero item.
+func paginate[T any](ctx context.Context, c *Client, path string, opts *ListOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		var firstItem json.RawMessage
+		limit := c.pageSizeFor(opts)
+
+		for skip := 0; ; skip += limit {
+			query := url.Values{}
+			if opts != nil {
+				for key, value := range opts.Filters {
+					if value != "" {
+						query.Set(key, value)
+					}
+				}
+			}
+			query.Set("skip", strconv.Itoa(skip))
+			query.Set("limit", strconv.Itoa(limit))
+
+			var page []json.RawMessage
+			requestID, err := c.do(ctx, http.MethodGet, path+"?"+query.Encode(), nil, &page)
+			if err != nil {
+				yield(zero, fmt.Errorf("failed to list page at offset %d: %w", skip, err))
+				return
+			}
+
+			// An endpoint that ignores skip returns the first page again; stop instead of looping
+			if len(page) > 0 {
+				if skip == 0 {
+					firstItem = page[0]
+				} else if bytes.Equal(page[0], firstItem) {
+					return
+				}
+			}
+
+			for _, raw := range page {
+				var item T
+				if err := json.Unmarshal(raw, &item); err != nil {
+					yield(zero, &RequestError{Method: http.MethodGet, Path: path, RequestID: requestID, Err: fmt.Errorf("failed to parse response: %w", err)})
+					return
+				}
+
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			// A short page is the last one. A page larger than the limit means the endpoint
+			// ignored the paging parameters and already returned everything.
+			if len(page) != limit {
+				return
+			}
+		}
+	}
+}
+
+// collect drains an iterator into a slice, stopping at the first error
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	items := []T{}
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,112 @@
+package controlplane
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// CreatePolicy creates a new policy
+func (c *Client) CreatePolicy(ctx context.Context, req *PolicyCreateRequest) (*Policy, error) {
+	var policy Policy
+	if err := c.Do(ctx, http.MethodPost, "/api/v1/policies", req, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// GetPolicy retrieves a policy by ID
+func (c *Client) GetPolicy(ctx context.Context, id string) (*Policy, error) {
+	var policy Policy
+	if err := c.Do(ctx, http.MethodGet, "/api/v1/policies/"+url.PathEscape(id), nil, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// UpdatePolicy updates an existing policy
+func (c *Client) UpdatePolicy(ctx context.Context, id string, req *PolicyUpdateRequest) (*Policy, error) {
+	var policy Policy
+	if err := c.Do(ctx, http.MethodPut, "/api/v1/policies/"+url.PathEscape(id), req, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// DeletePolicy deletes a policy
+func (c *Client) DeletePolicy(ctx context.Context, id string) error {
+	return c.Do(ctx, http.MethodDelete, "/api/v1/policies/"+url.PathEscape(id), nil, nil)
+}
+
+// ListPolicies lists all policies
+func (c *Client) ListPolicies(ctx context.Context, opts *ListOptions) ([]*Policy, error) {
+	return collect(c.IterPolicies(ctx, opts))
+}
+
+// IterPolicies iterates over all policies, fetching them page by page
+func (c *Client) IterPolicies(ctx context.Context, opts *ListOptions) iter.Seq2[*Policy, error] {
+	return paginate[*Policy](ctx, c, "/api/v1/policies", opts)
+}
+
+// GetPolicyVersion retrieves one version of a policy's content
+func (c *Client) GetPolicyVersion(ctx context.Context, policyID string, version int64) (*PolicyVersion, error) {
+	var policyVersion PolicyVersion
+	path := "/api/v1/policies/" + url.PathEscape(policyID) + "/versions/" + strconv.FormatInt(version, 10)
+	if err := c.Do(ctx, http.MethodGet, path, nil, &policyVersion); err != nil {
+		return nil, err
+	}
+
+	return &policyVersion, nil
+}
+
+// ListPolicyVersions lists every version of a policy's content, oldest first
+func (c *Client) ListPolicyVersions(ctx context.Context, policyID string, opts *ListOptions) ([]*PolicyVersion, error) {
+	return collect(c.IterPolicyVersions(ctx, policyID, opts))
+}
+
+// IterPolicyVersions iterates over every version of a policy's content, oldest first, fetching them
+// page by page
+func (c *Client) IterPolicyVersions(ctx context.Context, policyID string, opts *ListOptions) iter.Seq2[*PolicyVersion, error] {
+	return paginate[*PolicyVersion](ctx, c, "/api/v1/policies/"+url.PathEscape(policyID)+"/versions", opts)
+}
+
+// CreatePolicyAssociation attaches a policy to an agent, team, environment or project
+func (c *Client) CreatePolicyAssociation(ctx context.Context, req *PolicyAssociationCreateRequest) (*PolicyAssociation, error) {
+	var association PolicyAssociation
+	if err := c.Do(ctx, http.MethodPost, "/api/v1/policies/associations", req, &association); err != nil {
+		return nil, err
+	}
+
+	return &association, nil
+}
+
+// GetPolicyAssociation retrieves a policy association by ID
+func (c *Client) GetPolicyAssociation(ctx context.Context, id string) (*PolicyAssociation, error) {
+	var association PolicyAssociation
+	if err := c.Do(ctx, http.MethodGet, "/api/v1/policies/associations/"+url.PathEscape(id), nil, &association); err != nil {
+		return nil, err
+	}
+
+	return &association, nil
+}
+
+// DeletePolicyAssociation detaches a policy
+func (c *Client) DeletePolicyAssociation(ctx context.Context, id string) error {
+	return c.Do(ctx, http.MethodDelete, "/api/v1/policies/associations/"+url.PathEscape(id), nil, nil)
+}
+
+// ListPolicyAssociations lists all policy associations. Filter on policy_id, entity_type or entity_id
+// through opts.
+func (c *Client) ListPolicyAssociations(ctx context.Context, opts *ListOptions) ([]*PolicyAssociation, error) {
+	return collect(c.IterPolicyAssociations(ctx, opts))
+}
+
+// IterPolicyAssociations iterates over all policy associations, fetching them page by page
+func (c *Client) IterPolicyAssociations(ctx context.Context, opts *ListOptions) iter.Seq2[*PolicyAssociation, error] {
+	return paginate[*PolicyAssociation](ctx, c, "/api/v1/policies/associations", opts)
+}
@@ -0,0 +1,87 @@
+package controlplane
+
+import "time"
+
+// PolicyType represents the type of policy
+type PolicyType string
+
+const (
+	PolicyTypeRego PolicyType = "rego"
+	PolicyTypeJSON PolicyType = "json"
+)
+
+// Policy represents an OPA policy in the control plane
+type Policy struct {
+	ID             string     `json:"id,omitempty"`
+	OrganizationID string     `json:"organization_id,omitempty"`
+	Name           string     `json:"name"`
+	Description    *string    `json:"description,omitempty"`
+	PolicyContent  string     `json:"policy_content"`
+	PolicyType     PolicyType `json:"policy_type,omitempty"`
+	Enabled        bool       `json:"enabled"`
+	Tags           []string   `json:"tags,omitempty"`
+	Version        int64      `json:"version,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+}
+
+// GetUpdatedAt returns when the policy was last updated, or nil when the API did not report it
+func (p *Policy) GetUpdatedAt() *time.Time {
+	return p.UpdatedAt
+}
+
+// PolicyCreateRequest represents the request to create a policy
+type PolicyCreateRequest struct {
+	Name          string     `json:"name"`
+	Description   *string    `json:"description,omitempty"`
+	PolicyContent string     `json:"policy_content"`
+	PolicyType    PolicyType `json:"policy_type,omitempty"`
+	Enabled       bool       `json:"enabled"`
+	Tags          []string   `json:"tags,omitempty"`
+}
+
+// PolicyUpdateRequest represents the request to update a policy
+type PolicyUpdateRequest struct {
+	Name          *string  `json:"name,omitempty"`
+	Description   *string  `json:"description,omitempty"`
+	PolicyContent *string  `json:"policy_content,omitempty"`
+	Enabled       *bool    `json:"enabled,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+// PolicyVersion is a revision of a policy's content. The API records one every time the content changes.
+type PolicyVersion struct {
+	PolicyID      string     `json:"policy_id,omitempty"`
+	Version       int64      `json:"version"`
+	PolicyContent string     `json:"policy_content"`
+	CreatedBy     *string    `json:"created_by,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+}
+
+// PolicyEntityType is the kind of object a policy can be attached to
+type PolicyEntityType string
+
+const (
+	PolicyEntityAgent       PolicyEntityType = "agent"
+	PolicyEntityTeam        PolicyEntityType = "team"
+	PolicyEntityEnvironment PolicyEntityType = "environment"
+	PolicyEntityProject     PolicyEntityType = "project"
+)
+
+// PolicyAssociation attaches a policy to an agent, team, environment or project
+type PolicyAssociation struct {
+	ID             string           `json:"id,omitempty"`
+	OrganizationID string           `json:"organization_id,omitempty"`
+	PolicyID       string           `json:"policy_id"`
+	EntityType     PolicyEntityType `json:"entity_type"`
+	EntityID       string           `json:"entity_id"`
+	CreatedAt      *time.Time       `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time       `json:"updated_at,omitempty"`
+}
+
+// PolicyAssociationCreateRequest represents the request to attach a policy
+type PolicyAssociationCreateRequest struct {
+	PolicyID   string           `json:"policy_id"`
+	EntityType PolicyEntityType `json:"entity_type"`
+	EntityID   string           `json:"entity_id"`
+}
@@ -0,0 +1,53 @@
+package controlplane
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// CreateProject creates a new project
+func (c *Client) CreateProject(ctx context.Context, req *ProjectCreateRequest) (*Project, error) {
+	var project Project
+	if err := c.Do(ctx, http.MethodPost, "/api/v1/projects", req, &project); err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// GetProject retrieves a project by ID
+func (c *Client) GetProject(ctx context.Context, id string) (*Project, error) {
+	var project Project
+	if err := c.Do(ctx, http.MethodGet, "/api/v1/projects/"+url.PathEscape(id), nil, &project); err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// UpdateProject updates an existing project
+func (c *Client) UpdateProject(ctx context.Context, id string, req *ProjectUpdateRequest) (*Project, error) {
+	var project Project
+	if err := c.Do(ctx, http.MethodPatch, "/api/v1/projects/"+url.PathEscape(id), req, &project); err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
+
+// DeleteProject deletes a project
+func (c *Client) DeleteProject(ctx context.Context, id string) error {
+	return c.Do(ctx, http.MethodDelete, "/api/v1/projects/"+url.PathEscape(id), nil, nil)
+}
+
+// ListProjects lists all projects
+func (c *Client) ListProjects(ctx context.Context, opts *ListOptions) ([]*Project, error) {
+	return collect(c.IterProjects(ctx, opts))
+}
+
+// IterProjects iterates over all projects, fetching them page by page
+func (c *Client) IterProjects(ctx context.Context, opts *ListOptions) iter.Seq2[*Project, error] {
+	return paginate[*Project](ctx, c, "/api/v1/projects", opts)
+}
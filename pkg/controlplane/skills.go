@@ -0,0 +1,65 @@
+package controlplane
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// CreateSkill creates a new skill
+func (c *Client) CreateSkill(ctx context.Context, req *SkillCreateRequest) (*Skill, error) {
+	var skill Skill
+	if err := c.Do(ctx, http.MethodPost, "/api/v1/skills", req, &skill); err != nil {
+		return nil, err
+	}
+
+	return &skill, nil
+}
+
+// GetSkill retrieves a skill by ID
+func (c *Client) GetSkill(ctx context.Context, id string) (*Skill, error) {
+	var skill Skill
+	if err := c.Do(ctx, http.MethodGet, "/api/v1/skills/"+url.PathEscape(id), nil, &skill); err != nil {
+		return nil, err
+	}
+
+	return &skill, nil
+}
+
+// UpdateSkill updates an existing skill
+func (c *Client) UpdateSkill(ctx context.Context, id string, req *SkillUpdateRequest) (*Skill, error) {
+	var skill Skill
+	if err := c.Do(ctx, http.MethodPatch, "/api/v1/skills/"+url.PathEscape(id), req, &skill); err != nil {
+		return nil, err
+	}
+
+	return &skill, nil
+}
+
+// DeleteSkill deletes a skill
+func (c *Client) DeleteSkill(ctx context.Context, id string) error {
+	return c.Do(ctx, http.MethodDelete, "/api/v1/skills/"+url.PathEscape(id), nil, nil)
+}
+
+// ListSkills lists all skills
+func (c *Client) ListSkills(ctx context.Context, opts *ListOptions) ([]*Skill, error) {
+	return collect(c.IterSkills(ctx, opts))
+}
+
+// IterSkills iterates over all skills, fetching them page by page
+func (c *Client) IterSkills(ctx context.Context, opts *ListOptions) iter.Seq2[*Skill, error] {
+	return paginate[*Skill](ctx, c, "/api/v1/skills", opts)
+}
+
+// ListSkillDefinitions lists every skill type the control plane supports, including the custom types
+// registered by the organization
+func (c *Client) ListSkillDefinitions(ctx context.Context, opts *ListOptions) ([]*SkillDefinition, error) {
+	return collect(c.IterSkillDefinitions(ctx, opts))
+}
+
+// IterSkillDefinitions iterates over every skill type the control plane supports, fetching them page
+// by page
+func (c *Client) IterSkillDefinitions(ctx context.Context, opts *ListOptions) iter.Seq2[*SkillDefinition, error] {
+	return paginate[*SkillDefinition](ctx, c, "/api/v1/skills/definitions", opts)
+}
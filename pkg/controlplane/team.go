@@ -0,0 +1,73 @@
+package controlplane
+
+import "time"
+
+// TeamStatus represents the status of a team
+type TeamStatus string
+
+const (
+	TeamStatusActive   TeamStatus = "active"
+	TeamStatusInactive TeamStatus = "inactive"
+	TeamStatusArchived TeamStatus = "archived"
+)
+
+// TeamRole represents the role of an agent in its team
+type TeamRole string
+
+const (
+	TeamRoleMember TeamRole = "member"
+	TeamRoleLeader TeamRole = "leader"
+)
+
+// Team represents a team in the control plane. Agents is only returned when a single team is fetched.
+type Team struct {
+	ID                   string                 `json:"id,omitempty"`
+	OrganizationID       string                 `json:"organization_id,omitempty"`
+	Name                 string                 `json:"name"`
+	Description          *string                `json:"description,omitempty"`
+	Status               TeamStatus             `json:"status,omitempty"`
+	Runtime              *string                `json:"runtime,omitempty"`
+	Configuration        map[string]interface{} `json:"configuration,omitempty"`
+	SkillIDs             []string               `json:"skill_ids,omitempty"`
+	ExecutionEnvironment map[string]interface{} `json:"execution_environment,omitempty"`
+	Agents               []*TeamMember          `json:"agents,omitempty"`
+	CreatedAt            *time.Time             `json:"created_at,omitempty"`
+	UpdatedAt            *time.Time             `json:"updated_at,omitempty"`
+}
+
+// GetUpdatedAt returns when the team was last updated, or nil when the API did not report it
+func (t *Team) GetUpdatedAt() *time.Time {
+	return t.UpdatedAt
+}
+
+// TeamMember represents an agent in a team, with its role in the team
+type TeamMember struct {
+	Agent
+	Role TeamRole `json:"role,omitempty"`
+}
+
+// TeamMemberRequest represents the request to add an agent to a team or change its role
+type TeamMemberRequest struct {
+	Role *TeamRole `json:"role,omitempty"`
+}
+
+// TeamCreateRequest represents the request to create a team
+type TeamCreateRequest struct {
+	Name                 string                 `json:"name"`
+	Description          *string                `json:"description,omitempty"`
+	Runtime              *string                `json:"runtime,omitempty"`
+	Configuration        map[string]interface{} `json:"configuration,omitempty"`
+	SkillIDs             []string               `json:"skill_ids,omitempty"`
+	ExecutionEnvironment map[string]interface{} `json:"execution_environment,omitempty"`
+}
+
+// TeamUpdateRequest represents the request to update a team
+type TeamUpdateRequest struct {
+	Name                 *string                `json:"name,omitempty"`
+	Description          *string                `json:"description,omitempty"`
+	Status               *TeamStatus            `json:"status,omitempty"`
+	Runtime              *string                `json:"runtime,omitempty"`
+	Configuration        map[string]interface{} `json:"configuration,omitempty"`
+	SkillIDs             []string               `json:"skill_ids,omitempty"`
+	ExecutionEnvironment map[string]interface{} `json:"execution_environment,omitempty"`
+}
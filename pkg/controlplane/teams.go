@@ -0,0 +1,80 @@
+package controlplane
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// CreateTeam creates a new team
+func (c *Client) CreateTeam(ctx context.Context, req *TeamCreateRequest) (*Team, error) {
+	var team Team
+	if err := c.Do(ctx, http.MethodPost, "/api/v1/teams", req, &team); err != nil {
+		return nil, err
+	}
+
+	return &team, nil
+}
+
+// GetTeam retrieves a team by ID
+func (c *Client) GetTeam(ctx context.Context, id string) (*Team, error) {
+	var team Team
+	if err := c.Do(ctx, http.MethodGet, "/api/v1/teams/"+url.PathEscape(id), nil, &team); err != nil {
+		return nil, err
+	}
+
+	return &team, nil
+}
+
+// UpdateTeam updates an existing team
+func (c *Client) UpdateTeam(ctx context.Context, id string, req *TeamUpdateRequest) (*Team, error) {
+	var team Team
+	if err := c.Do(ctx, http.MethodPatch, "/api/v1/teams/"+url.PathEscape(id), req, &team); err != nil {
+		return nil, err
+	}
+
+	return &team, nil
+}
+
+// DeleteTeam deletes a team
+func (c *Client) DeleteTeam(ctx context.Context, id string) error {
+	return c.Do(ctx, http.MethodDelete, "/api/v1/teams/"+url.PathEscape(id), nil, nil)
+}
+
+// ListTeams lists all teams
+func (c *Client) ListTeams(ctx context.Context, opts *ListOptions) ([]*Team, error) {
+	return collect(c.IterTeams(ctx, opts))
+}
+
+// IterTeams iterates over all teams, fetching them page by page
+func (c *Client) IterTeams(ctx context.Context, opts *ListOptions) iter.Seq2[*Team, error] {
+	return paginate[*Team](ctx, c, "/api/v1/teams", opts)
+}
+
+// AddTeamMember adds an agent to a team, moving it out of the team it was in
+func (c *Client) AddTeamMember(ctx context.Context, teamID, agentID string, req *TeamMemberRequest) (*TeamMember, error) {
+	var member TeamMember
+	path := "/api/v1/teams/" + url.PathEscape(teamID) + "/agents/" + url.PathEscape(agentID)
+	if err := c.Do(ctx, http.MethodPost, path, req, &member); err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// UpdateTeamMember changes the role of an agent in its team
+func (c *Client) UpdateTeamMember(ctx context.Context, teamID, agentID string, req *TeamMemberRequest) (*TeamMember, error) {
+	var member TeamMember
+	path := "/api/v1/teams/" + url.PathEscape(teamID) + "/agents/" + url.PathEscape(agentID)
+	if err := c.Do(ctx, http.MethodPatch, path, req, &member); err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// RemoveTeamMember removes an agent from a team
+func (c *Client) RemoveTeamMember(ctx context.Context, teamID, agentID string) error {
+	return c.Do(ctx, http.MethodDelete, "/api/v1/teams/"+url.PathEscape(teamID)+"/agents/"+url.PathEscape(agentID), nil, nil)
+}
@@ -0,0 +1,53 @@
+package controlplane
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// CreateWorkerQueue creates a new worker queue in an environment
+func (c *Client) CreateWorkerQueue(ctx context.Context, environmentID string, req *WorkerQueueCreateRequest) (*WorkerQueue, error) {
+	var queue WorkerQueue
+	if err := c.Do(ctx, http.MethodPost, "/api/v1/environments/"+url.PathEscape(environmentID)+"/worker-queues", req, &queue); err != nil {
+		return nil, err
+	}
+
+	return &queue, nil
+}
+
+// GetWorkerQueue retrieves a worker queue by ID
+func (c *Client) GetWorkerQueue(ctx context.Context, queueID string) (*WorkerQueue, error) {
+	var queue WorkerQueue
+	if err := c.Do(ctx, http.MethodGet, "/api/v1/worker-queues/"+url.PathEscape(queueID), nil, &queue); err != nil {
+		return nil, err
+	}
+
+	return &queue, nil
+}
+
+// UpdateWorkerQueue updates an existing worker queue
+func (c *Client) UpdateWorkerQueue(ctx context.Context, queueID string, req *WorkerQueueUpdateRequest) (*WorkerQueue, error) {
+	var queue WorkerQueue
+	if err := c.Do(ctx, http.MethodPatch, "/api/v1/worker-queues/"+url.PathEscape(queueID), req, &queue); err != nil {
+		return nil, err
+	}
+
+	return &queue, nil
+}
+
+// DeleteWorkerQueue deletes a worker queue
+func (c *Client) DeleteWorkerQueue(ctx context.Context, queueID string) error {
+	return c.Do(ctx, http.MethodDelete, "/api/v1/worker-queues/"+url.PathEscape(queueID), nil, nil)
+}
+
+// ListWorkerQueues lists all worker queues in an environment
+func (c *Client) ListWorkerQueues(ctx context.Context, environmentID string, opts *ListOptions) ([]*WorkerQueue, error) {
+	return collect(c.IterWorkerQueues(ctx, environmentID, opts))
+}
+
+// IterWorkerQueues iterates over all worker queues in an environment, fetching them page by page
+func (c *Client) IterWorkerQueues(ctx context.Context, environmentID string, opts *ListOptions) iter.Seq2[*WorkerQueue, error] {
+	return paginate[*WorkerQueue](ctx, c, "/api/v1/environments/"+url.PathEscape(environmentID)+"/worker-queues", opts)
+}
@@ -0,0 +1,67 @@
+package fakeserver
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fault makes matching requests slow or fail. A fault with only Latency delays requests that are
+// then served normally.
+type Fault struct {
+	// Method restricts the fault to one HTTP method; empty matches every method
+	Method string
+	// PathPrefix restricts the fault to paths starting with it, e.g. "/api/v1/agents"; empty matches every path
+	PathPrefix string
+	// Latency delays matching requests
+	Latency time.Duration
+	// StatusCode, when non-zero, is returned instead of serving the request, e.g. 429 or 500
+	StatusCode int
+	// RetryAfter is sent in the Retry-After header of 429 responses
+	RetryAfter time.Duration
+	// Times is how many requests the fault applies to before it is removed; zero applies it to every request
+	Times int
+}
+
+// InjectFault adds a fault. When several faults match a request, the earliest one applies.
+func (s *Server) InjectFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every fault
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// matchFault returns the fault that applies to a request, consuming one of its uses
+func (s *Server) matchFault(r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, fault := range s.faults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, fault.PathPrefix) {
+			continue
+		}
+
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+
+		matched := *fault
+		return &matched
+	}
+
+	return nil
+}
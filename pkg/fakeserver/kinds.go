@@ -0,0 +1,380 @@
+package fakeserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Kind is a collection of API objects, named after its route
+type Kind string
+
+const (
+	Agents       Kind = "agents"
+	Teams        Kind = "teams"
+	Projects     Kind = "projects"
+	Environments Kind = "environments"
+	WorkerQueues Kind = "worker-queues"
+	Skills       Kind = "skills"
+	Policies     Kind = "policies"
+	Jobs         Kind = "jobs"
+
+	PolicyAssociations Kind = "policies/associations"
+)
+
+// Kinds lists every kind the server stores
+var Kinds = []Kind{Agents, Teams, Projects, Environments, WorkerQueues, Skills, Policies, Jobs, PolicyAssociations}
+
+var (
+	agentStatuses       = []string{"idle", "running", "paused", "completed", "failed", "stopped"}
+	runtimes            = []string{"default", "claude_code"}
+	teamStatuses        = []string{"active", "inactive", "archived"}
+	projectStatuses     = []string{"active", "archived", "paused"}
+	projectVisibilities = []string{"private", "org"}
+	environmentStatuses = []string{"active", "inactive", "ready"}
+	queueStatuses       = []string{"active", "inactive", "paused"}
+	policyTypes         = []string{"rego", "json"}
+	triggerTypes        = []string{"cron", "webhook", "manual"}
+	planningModes       = []string{"on_the_fly", "predefined_agent", "predefined_team", "predefined_workflow"}
+	entityTypes         = []string{"agent", "team", "workflow"}
+	executorTypes       = []string{"auto", "specific_queue", "environment"}
+	policyEntityTypes   = []string{"agent", "team", "environment", "project"}
+	policyEntityKinds   = map[string]Kind{"agent": Agents, "team": Teams, "environment": Environments, "project": Projects}
+)
+
+func kindByPath(segment string) (Kind, bool) {
+	for _, kind := range Kinds {
+		if string(kind) == segment {
+			return kind, true
+		}
+	}
+
+	return "", false
+}
+
+// notFound returns the detail of a 404 response for the kind
+func (k Kind) notFound() string {
+	name := strings.TrimSuffix(strings.ReplaceAll(string(k), "-", " "), "s")
+	switch k {
+	case Policies:
+		name = "policy"
+	case PolicyAssociations:
+		name = "policy association"
+	}
+
+	return strings.ToUpper(name[:1]) + name[1:] + " not found"
+}
+
+// hasOrganization reports whether objects of the kind carry an organization_id
+func (k Kind) hasOrganization() bool {
+	return k != Agents
+}
+
+// validate checks a created or updated object and fills in server-side defaults. previous is the
+// stored object on update and nil on create. Must be called with s.mu held.
+func (s *Server) validate(kind Kind, object, previous Object) *apiError {
+	if kind == PolicyAssociations {
+		return s.validatePolicyAssociation(object, previous)
+	}
+
+	if err := requireString(object, "name"); err != nil {
+		return err
+	}
+
+	switch kind {
+	case Agents:
+		if err := enum(object, "status", agentStatuses, "idle"); err != nil {
+			return err
+		}
+		if err := enum(object, "runtime", runtimes, "default"); err != nil {
+			return err
+		}
+		if err := s.reference(object, "team_id", Teams); err != nil {
+			return err
+		}
+		// An agent assigned to a team through its own team_id joins as a member
+		if previous == nil || object["team_id"] != previous["team_id"] {
+			delete(object, "team_role")
+			if teamID, _ := object["team_id"].(string); teamID != "" {
+				object["team_role"] = "member"
+			}
+		}
+
+	case Teams:
+		if err := enum(object, "status", teamStatuses, "active"); err != nil {
+			return err
+		}
+		if err := enum(object, "runtime", runtimes, "default"); err != nil {
+			return err
+		}
+		if err := s.references(object, "skill_ids", Skills); err != nil {
+			return err
+		}
+
+	case Projects:
+		if err := requireString(object, "key"); err != nil {
+			return err
+		}
+		for id, other := range s.objects[Projects] {
+			if id != object["id"] && other["key"] == object["key"] {
+				return &apiError{status: http.StatusConflict, detail: fmt.Sprintf("Project with key %q already exists", object["key"])}
+			}
+		}
+		if err := enum(object, "status", projectStatuses, "active"); err != nil {
+			return err
+		}
+		if err := enum(object, "visibility", projectVisibilities, "private"); err != nil {
+			return err
+		}
+		defaultValue(object, "restrict_to_environment", false)
+		if err := s.references(object, "policy_ids", Policies); err != nil {
+			return err
+		}
+
+	case Environments:
+		if err := enum(object, "status", environmentStatuses, "ready"); err != nil {
+			return err
+		}
+		defaultValue(object, "worker_token", newSecret())
+
+	case WorkerQueues:
+		for id, other := range s.objects[WorkerQueues] {
+			if id != object["id"] && other["environment_id"] == object["environment_id"] && other["name"] == object["name"] {
+				return &apiError{status: http.StatusConflict, detail: fmt.Sprintf("Worker queue %q already exists in this environment", object["name"])}
+			}
+		}
+		if err := enum(object, "status", queueStatuses, "active"); err != nil {
+			return err
+		}
+		// Clients send heartbeat_interval as 0 when it is not configured
+		if object["heartbeat_interval"] == json.Number("0") {
+			delete(object, "heartbeat_interval")
+		}
+		defaultValue(object, "heartbeat_interval", json.Number("60"))
+		if err := positiveNumber(object, "heartbeat_interval"); err != nil {
+			return err
+		}
+		if _, ok := object["max_workers"]; ok {
+			if err := positiveNumber(object, "max_workers"); err != nil {
+				return err
+			}
+		}
+		defaultValue(object, "active_workers", json.Number("0"))
+		defaultValue(object, "task_queue_name", fmt.Sprintf("%s.%s", object["environment_id"], object["name"]))
+
+	case Skills:
+		if previous != nil && object["type"] != previous["type"] {
+			return errValidation("type cannot be changed")
+		}
+		if err := enum(object, "type", s.skillTypes(), ""); err != nil {
+			return err
+		}
+		defaultValue(object, "enabled", true)
+
+	case Policies:
+		if err := requireString(object, "policy_content"); err != nil {
+			return err
+		}
+		if err := enum(object, "policy_type", policyTypes, "rego"); err != nil {
+			return err
+		}
+		if object["policy_type"] == "json" && !json.Valid([]byte(object["policy_content"].(string))) {
+			return errValidation("policy_content must be valid JSON for policy_type json")
+		}
+		defaultValue(object, "enabled", true)
+
+		// The version counts content changes
+		switch {
+		case previous == nil:
+			object["version"] = json.Number("1")
+		case object["policy_content"] != previous["policy_content"]:
+			version, _ := previous["version"].(json.Number).Int64()
+			object["version"] = json.Number(fmt.Sprint(version + 1))
+		}
+
+	case Jobs:
+		return s.validateJob(object)
+	}
+
+	return nil
+}
+
+func (s *Server) validateJob(object Object) *apiError {
+	if err := requireString(object, "prompt_template"); err != nil {
+		return err
+	}
+	if err := enum(object, "trigger_type", triggerTypes, ""); err != nil {
+		return err
+	}
+	if err := enum(object, "planning_mode", planningModes, "predefined_agent"); err != nil {
+		return err
+	}
+	if err := enum(object, "executor_type", executorTypes, "auto"); err != nil {
+		return err
+	}
+
+	switch object["trigger_type"] {
+	case "cron":
+		if err := requireString(object, "cron_schedule"); err != nil {
+			return errValidation("cron_schedule is required when trigger_type is cron")
+		}
+		if len(strings.Fields(object["cron_schedule"].(string))) != 5 {
+			return errValidation("cron_schedule must have 5 fields")
+		}
+		defaultValue(object, "cron_timezone", "UTC")
+	case "webhook":
+		defaultValue(object, "webhook_url", fmt.Sprintf("https://control-plane.kubiya.ai/api/v1/jobs/%s/trigger", object["id"]))
+		defaultValue(object, "webhook_secret", newSecret())
+	}
+
+	if object["planning_mode"] != "on_the_fly" {
+		if err := enum(object, "entity_type", entityTypes, ""); err != nil {
+			return errValidation("entity_type is required when planning_mode is not on_the_fly")
+		}
+		if err := requireString(object, "entity_id"); err != nil {
+			return errValidation("entity_id is required when planning_mode is not on_the_fly")
+		}
+	}
+
+	switch object["executor_type"] {
+	case "specific_queue":
+		if err := requireString(object, "worker_queue_name"); err != nil {
+			return errValidation("worker_queue_name is required when executor_type is specific_queue")
+		}
+	case "environment":
+		if err := requireString(object, "environment_name"); err != nil {
+			return errValidation("environment_name is required when executor_type is environment")
+		}
+	}
+
+	enabled, _ := object["enabled"].(bool)
+	object["enabled"] = enabled
+	object["status"] = jobStatus(enabled)
+
+	return nil
+}
+
+func (s *Server) validatePolicyAssociation(object, previous Object) *apiError {
+	if previous != nil {
+		for _, field := range []string{"policy_id", "entity_type", "entity_id"} {
+			if object[field] != previous[field] {
+				return errValidation(field + " cannot be changed")
+			}
+		}
+		return nil
+	}
+
+	if err := requireString(object, "policy_id"); err != nil {
+		return err
+	}
+	if err := s.reference(object, "policy_id", Policies); err != nil {
+		return err
+	}
+	if err := enum(object, "entity_type", policyEntityTypes, ""); err != nil {
+		return err
+	}
+	if err := requireString(object, "entity_id"); err != nil {
+		return err
+	}
+	if err := s.reference(object, "entity_id", policyEntityKinds[object["entity_type"].(string)]); err != nil {
+		return err
+	}
+
+	for _, other := range s.objects[PolicyAssociations] {
+		if other["policy_id"] == object["policy_id"] && other["entity_type"] == object["entity_type"] && other["entity_id"] == object["entity_id"] {
+			return &apiError{status: http.StatusConflict, detail: fmt.Sprintf("Policy is already attached to %s %s", object["entity_type"], object["entity_id"])}
+		}
+	}
+
+	return nil
+}
+
+// jobStatus returns the status of a job with the given enabled flag
+func jobStatus(enabled bool) string {
+	if enabled {
+		return "active"
+	}
+
+	return "disabled"
+}
+
+// requireString checks that field is a non-empty string
+func requireString(object Object, field string) *apiError {
+	value, ok := object[field].(string)
+	if !ok || strings.TrimSpace(value) == "" {
+		return errValidation(field + " is required")
+	}
+
+	return nil
+}
+
+// enum checks that field is one of allowed, setting it to fallback when it is missing. An empty
+// fallback makes the field required.
+func enum(object Object, field string, allowed []string, fallback string) *apiError {
+	if _, ok := object[field]; !ok && fallback != "" {
+		object[field] = fallback
+	}
+
+	value, _ := object[field].(string)
+	for _, candidate := range allowed {
+		if value == candidate {
+			return nil
+		}
+	}
+
+	return errValidation(fmt.Sprintf("%s must be one of %s", field, strings.Join(allowed, ", ")))
+}
+
+// defaultValue sets field to value when it is missing
+func defaultValue(object Object, field string, value interface{}) {
+	if _, ok := object[field]; !ok {
+		object[field] = value
+	}
+}
+
+// positiveNumber checks that field is a positive integer
+func positiveNumber(object Object, field string) *apiError {
+	number, ok := object[field].(json.Number)
+	if !ok {
+		return errValidation(field + " must be a number")
+	}
+
+	if n, err := number.Int64(); err != nil || n <= 0 {
+		return errValidation(field + " must be a positive integer")
+	}
+
+	return nil
+}
+
+// reference checks that field, when set, is the ID of an existing object of the given kind
+func (s *Server) reference(object Object, field string, kind Kind) *apiError {
+	id, ok := object[field].(string)
+	if !ok || id == "" {
+		return nil
+	}
+
+	if _, exists := s.objects[kind][id]; !exists {
+		return errValidation(fmt.Sprintf("%s: %s", field, kind.notFound()))
+	}
+
+	return nil
+}
+
+// references checks that every ID in the list field belongs to an existing object of the given kind
+func (s *Server) references(object Object, field string, kind Kind) *apiError {
+	ids, _ := object[field].([]interface{})
+
+	missing := map[string]bool{}
+	for _, id := range ids {
+		if _, exists := s.objects[kind][fmt.Sprint(id)]; !exists {
+			missing[fmt.Sprint(id)] = true
+		}
+	}
+
+	if len(missing) > 0 {
+		return errValidation(fmt.Sprintf("%s: unknown IDs %s", field, strings.Join(sortedKeys(missing), ", ")))
+	}
+
+	return nil
+}
@@ -0,0 +1,646 @@
+// Package fakeserver implements an in-memory Kubiya Control Plane API for tests.
+//
+// The server keeps agents, teams, projects, environments, worker queues, skills, policies, policy
+// associations and jobs in memory and answers the same routes as the real API under /api/v1. It
+// assigns IDs and timestamps, validates requests, returns 404 for unknown objects and supports
+// skip/limit paging and equality filters on list endpoints. Policies keep a history of their content
+// versions, and skill types are checked against a catalog of skill definitions. A single team is
+// returned with its agents, which join and leave it through the team's agents routes. Faults such as
+// latency, 429 and 500 responses can be injected per route.
+//
+// Start it with httptest and point clients at its URL, for example through
+// KUBIYA_CONTROL_PLANE_BASE_URL:
+//
+//	server := httptest.NewServer(fakeserver.New())
+//	defer server.Close()
+package fakeserver
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// apiPrefix is the path prefix of every API route
+const apiPrefix = "/api/v1/"
+
+// OrganizationID is the organization every object created by the server belongs to
+const OrganizationID = "org-fake"
+
+// UserEmail is the user the server records as the author of every policy version
+const UserEmail = "fake-user@example.com"
+
+// Object is a stored API object in its JSON representation
+type Object map[string]interface{}
+
+// Server is an in-memory Control Plane API. The zero value is not usable; create servers with New.
+type Server struct {
+	// APIKey, when set, is the only bearer token the server accepts. Otherwise any non-empty token is accepted.
+	APIKey string
+	// Now returns the time used for created_at and updated_at. Defaults to time.Now.
+	Now func() time.Time
+
+	mu          sync.Mutex
+	objects     map[Kind]map[string]Object
+	order       map[Kind][]string
+	versions    map[string][]Object
+	definitions []Object
+	faults      []*Fault
+}
+
+// New returns an empty server
+func New() *Server {
+	s := &Server{Now: time.Now}
+	s.Reset()
+	return s
+}
+
+// Reset deletes every object, registered skill definition and fault
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects = make(map[Kind]map[string]Object)
+	s.order = make(map[Kind][]string)
+	s.versions = make(map[string][]Object)
+	s.definitions = builtinSkillDefinitions()
+	for _, kind := range Kinds {
+		s.objects[kind] = make(map[string]Object)
+	}
+	s.faults = nil
+}
+
+// Get returns a copy of the object of the given kind with the given ID
+func (s *Server) Get(kind Kind, id string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[kind][id]
+	if !ok {
+		return nil, false
+	}
+
+	return object.clone(), true
+}
+
+// List returns copies of all objects of the given kind in creation order
+func (s *Server) List(kind Kind) []Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	objects := make([]Object, 0, len(s.order[kind]))
+	for _, id := range s.order[kind] {
+		objects = append(objects, s.objects[kind][id].clone())
+	}
+
+	return objects
+}
+
+// Modify changes a stored object in place, as a change made outside the API client under test
+// would, and bumps its updated_at. It reports whether the object exists.
+func (s *Server) Modify(kind Kind, id string, change func(Object)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[kind][id]
+	if !ok {
+		return false
+	}
+
+	change(object)
+	object["updated_at"] = s.timestamp()
+	return true
+}
+
+// ServeHTTP implements the http.Handler interface
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Like the API, answer every request with a request ID of the server's own
+	w.Header().Set("X-Request-ID", newID())
+
+	if fault := s.matchFault(r); fault != nil {
+		if fault.Latency > 0 {
+			select {
+			case <-time.After(fault.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if fault.StatusCode != 0 {
+			if fault.StatusCode == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter/time.Second)))
+			}
+			writeError(w, &apiError{status: fault.StatusCode, detail: http.StatusText(fault.StatusCode)})
+			return
+		}
+	}
+
+	if err := s.authorize(r); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	status, body, err := s.route(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, status, body)
+}
+
+func (s *Server) authorize(r *http.Request) *apiError {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return &apiError{status: http.StatusUnauthorized, detail: "Not authenticated"}
+	}
+
+	if s.APIKey != "" && token != s.APIKey {
+		return &apiError{status: http.StatusUnauthorized, detail: "Invalid API key"}
+	}
+
+	return nil
+}
+
+// route dispatches a request to the handler for its path and method
+func (s *Server) route(r *http.Request) (int, interface{}, *apiError) {
+	rest, ok := strings.CutPrefix(r.URL.Path, apiPrefix)
+	if !ok {
+		return 0, nil, errNotFound("Not Found")
+	}
+
+	segments := strings.Split(strings.Trim(rest, "/"), "/")
+	kind, ok := kindByPath(segments[0])
+	if !ok {
+		return 0, nil, errNotFound("Not Found")
+	}
+	if kind == Policies && len(segments) > 1 && segments[1] == "associations" {
+		kind, segments = PolicyAssociations, segments[1:]
+	}
+
+	switch {
+	case len(segments) == 2 && kind == Skills && segments[1] == "definitions":
+		if r.Method == http.MethodGet {
+			return s.listSkillDefinitions(r)
+		}
+
+	case len(segments) == 1 && kind != WorkerQueues:
+		switch r.Method {
+		case http.MethodGet:
+			return s.list(kind, r, "")
+		case http.MethodPost:
+			return s.create(kind, r, "")
+		}
+
+	case len(segments) == 2:
+		switch r.Method {
+		case http.MethodGet:
+			return s.get(kind, segments[1])
+		case http.MethodPatch, http.MethodPut:
+			return s.update(kind, segments[1], r)
+		case http.MethodDelete:
+			return s.delete(kind, segments[1])
+		}
+
+	case len(segments) == 3 && kind == Environments && segments[2] == "worker-queues":
+		switch r.Method {
+		case http.MethodGet:
+			return s.list(WorkerQueues, r, segments[1])
+		case http.MethodPost:
+			return s.create(WorkerQueues, r, segments[1])
+		}
+
+	case len(segments) == 3 && kind == Jobs && (segments[2] == "enable" || segments[2] == "disable"):
+		if r.Method == http.MethodPost {
+			return s.setJobEnabled(segments[1], segments[2] == "enable")
+		}
+
+	case len(segments) == 3 && kind == Policies && segments[2] == "versions":
+		if r.Method == http.MethodGet {
+			return s.listPolicyVersions(segments[1], r)
+		}
+
+	case len(segments) == 4 && kind == Teams && segments[2] == "agents":
+		switch r.Method {
+		case http.MethodPost:
+			return s.addTeamMember(segments[1], segments[3], r)
+		case http.MethodPatch:
+			return s.updateTeamMember(segments[1], segments[3], r)
+		case http.MethodDelete:
+			return s.removeTeamMember(segments[1], segments[3])
+		}
+
+	case len(segments) == 4 && kind == Policies && segments[2] == "versions":
+		if r.Method == http.MethodGet {
+			return s.getPolicyVersion(segments[1], segments[3])
+		}
+
+	default:
+		return 0, nil, errNotFound("Not Found")
+	}
+
+	return 0, nil, &apiError{status: http.StatusMethodNotAllowed, detail: "Method Not Allowed"}
+}
+
+func (s *Server) list(kind Kind, r *http.Request, environmentID string) (int, interface{}, *apiError) {
+	query := r.URL.Query()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if environmentID != "" {
+		if _, ok := s.objects[Environments][environmentID]; !ok {
+			return 0, nil, errNotFound(Environments.notFound())
+		}
+	}
+
+	matching := []Object{}
+	for _, id := range s.order[kind] {
+		object := s.objects[kind][id]
+		if environmentID != "" && object["environment_id"] != environmentID {
+			continue
+		}
+		if matchesQuery(object, query) {
+			matching = append(matching, object)
+		}
+	}
+
+	return page(matching, query)
+}
+
+func (s *Server) get(kind Kind, id string) (int, interface{}, *apiError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[kind][id]
+	if !ok {
+		return 0, nil, errNotFound(kind.notFound())
+	}
+
+	// A single team is returned with its agents
+	if kind == Teams {
+		object = object.clone()
+		object["agents"] = s.teamMembers(id)
+	}
+
+	return http.StatusOK, object, nil
+}
+
+func (s *Server) create(kind Kind, r *http.Request, environmentID string) (int, interface{}, *apiError) {
+	input, err := decodeObject(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if environmentID != "" {
+		if _, ok := s.objects[Environments][environmentID]; !ok {
+			return 0, nil, errNotFound(Environments.notFound())
+		}
+		input["environment_id"] = environmentID
+	}
+
+	object := Object{}
+	for key, value := range input {
+		if value != nil {
+			object[key] = value
+		}
+	}
+
+	now := s.timestamp()
+	object["id"] = newID()
+	object["created_at"] = now
+	object["updated_at"] = now
+	if kind.hasOrganization() {
+		object["organization_id"] = OrganizationID
+	}
+
+	if err := s.validate(kind, object, nil); err != nil {
+		return 0, nil, err
+	}
+
+	id := object["id"].(string)
+	s.objects[kind][id] = object
+	s.order[kind] = append(s.order[kind], id)
+	if kind == Policies {
+		s.recordPolicyVersion(object)
+	}
+
+	return http.StatusCreated, object, nil
+}
+
+func (s *Server) update(kind Kind, id string, r *http.Request) (int, interface{}, *apiError) {
+	patch, err := decodeObject(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.objects[kind][id]
+	if !ok {
+		return 0, nil, errNotFound(kind.notFound())
+	}
+
+	// Validate a copy so that a rejected update leaves the stored object untouched
+	object := current.clone()
+	for key, value := range patch {
+		if readOnlyFields[key] {
+			continue
+		}
+		if value == nil {
+			delete(object, key)
+			continue
+		}
+		object[key] = value
+	}
+	object["updated_at"] = s.timestamp()
+
+	if err := s.validate(kind, object, current); err != nil {
+		return 0, nil, err
+	}
+
+	s.objects[kind][id] = object
+	if kind == Policies && object["version"] != current["version"] {
+		s.recordPolicyVersion(object)
+	}
+
+	return http.StatusOK, object, nil
+}
+
+func (s *Server) delete(kind Kind, id string) (int, interface{}, *apiError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.objects[kind][id]; !ok {
+		return 0, nil, errNotFound(kind.notFound())
+	}
+
+	if kind == Environments {
+		for _, queue := range s.objects[WorkerQueues] {
+			if queue["environment_id"] == id {
+				return 0, nil, &apiError{status: http.StatusConflict, detail: "Environment still has worker queues"}
+			}
+		}
+	}
+
+	s.remove(kind, id)
+
+	// Agents of a deleted team no longer belong to any team
+	if kind == Teams {
+		for _, agent := range s.objects[Agents] {
+			if agent["team_id"] == id {
+				delete(agent, "team_id")
+				delete(agent, "team_role")
+			}
+		}
+	}
+
+	// Deleting a policy or the object it is attached to removes the attachment
+	for associationID, association := range s.objects[PolicyAssociations] {
+		if association["policy_id"] == id || association["entity_id"] == id {
+			s.remove(PolicyAssociations, associationID)
+		}
+	}
+
+	return http.StatusNoContent, nil, nil
+}
+
+// remove deletes a stored object. Must be called with s.mu held.
+func (s *Server) remove(kind Kind, id string) {
+	delete(s.objects[kind], id)
+	delete(s.versions, id)
+	for i, existing := range s.order[kind] {
+		if existing == id {
+			s.order[kind] = append(s.order[kind][:i:i], s.order[kind][i+1:]...)
+			break
+		}
+	}
+}
+
+func (s *Server) listPolicyVersions(policyID string, r *http.Request) (int, interface{}, *apiError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.objects[Policies][policyID]; !ok {
+		return 0, nil, errNotFound(Policies.notFound())
+	}
+
+	return page(s.versions[policyID], r.URL.Query())
+}
+
+func (s *Server) getPolicyVersion(policyID, version string) (int, interface{}, *apiError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.objects[Policies][policyID]; !ok {
+		return 0, nil, errNotFound(Policies.notFound())
+	}
+
+	for _, object := range s.versions[policyID] {
+		if object["version"].(json.Number).String() == version {
+			return http.StatusOK, object, nil
+		}
+	}
+
+	return 0, nil, errNotFound("Policy version not found")
+}
+
+// recordPolicyVersion appends the current content of a policy to its history. Must be called with
+// s.mu held.
+func (s *Server) recordPolicyVersion(policy Object) {
+	id := policy["id"].(string)
+	s.versions[id] = append(s.versions[id], Object{
+		"policy_id":      id,
+		"version":        policy["version"],
+		"policy_content": policy["policy_content"],
+		"created_by":     UserEmail,
+		"created_at":     policy["updated_at"],
+	})
+}
+
+func (s *Server) setJobEnabled(id string, enabled bool) (int, interface{}, *apiError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.objects[Jobs][id]
+	if !ok {
+		return 0, nil, errNotFound(Jobs.notFound())
+	}
+
+	job["enabled"] = enabled
+	job["status"] = jobStatus(enabled)
+	job["updated_at"] = s.timestamp()
+
+	return http.StatusOK, job, nil
+}
+
+// timestamp returns the current time in the format the API uses
+func (s *Server) timestamp() string {
+	return s.Now().UTC().Format(time.RFC3339Nano)
+}
+
+// readOnlyFields are ignored in update requests
+var readOnlyFields = map[string]bool{
+	"id":              true,
+	"organization_id": true,
+	"environment_id":  true,
+	"created_at":      true,
+	"updated_at":      true,
+}
+
+// matchesQuery reports whether an object matches every filter in the query. Scalars match by their
+// string form; list fields match when they contain the value.
+func matchesQuery(object Object, query map[string][]string) bool {
+	for key, values := range query {
+		if key == "skip" || key == "limit" {
+			continue
+		}
+
+		for _, want := range values {
+			if !matchesValue(object[key], want) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func matchesValue(value interface{}, want string) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case []interface{}:
+		for _, item := range v {
+			if fmt.Sprint(item) == want {
+				return true
+			}
+		}
+		return false
+	default:
+		return fmt.Sprint(v) == want
+	}
+}
+
+// page returns the page of objects selected by the skip and limit query parameters
+func page(objects []Object, query url.Values) (int, interface{}, *apiError) {
+	skip, err := queryInt(query.Get("skip"), 0)
+	if err != nil {
+		return 0, nil, err
+	}
+	limit, err := queryInt(query.Get("limit"), 100)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	selected := []Object{}
+	if skip < len(objects) {
+		selected = objects[skip:min(skip+limit, len(objects))]
+	}
+
+	return http.StatusOK, selected, nil
+}
+
+func queryInt(value string, fallback int) (int, *apiError) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, errValidation(fmt.Sprintf("invalid paging parameter %q", value))
+	}
+
+	return n, nil
+}
+
+func decodeObject(r *http.Request) (Object, *apiError) {
+	object := Object{}
+	if r.Body == nil {
+		return object, nil
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&object); err != nil {
+		return nil, errValidation("request body must be a JSON object: " + err.Error())
+	}
+
+	return object, nil
+}
+
+// clone returns a deep copy of the object
+func (o Object) clone() Object {
+	data, _ := json.Marshal(o)
+	var copied Object
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	_ = decoder.Decode(&copied)
+	return copied
+}
+
+// newID returns a random UUID v4
+func newID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// newSecret returns a random token for generated credentials
+func newSecret() string {
+	var b [24]byte
+	_, _ = rand.Read(b[:])
+	return fmt.Sprintf("%x", b)
+}
+
+// apiError is an error response in the API's {"detail": ...} format
+type apiError struct {
+	status int
+	detail string
+}
+
+func errNotFound(detail string) *apiError {
+	return &apiError{status: http.StatusNotFound, detail: detail}
+}
+
+func errValidation(detail string) *apiError {
+	return &apiError{status: http.StatusUnprocessableEntity, detail: detail}
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	writeJSON(w, err.status, map[string]string{"detail": err.detail})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	if status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// sortedKeys returns the keys of a set in order, for stable error messages
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,321 @@
+package fakeserver_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+	"terraform-provider-kubiya-control-plane/internal/entities"
+	"terraform-provider-kubiya-control-plane/pkg/fakeserver"
+)
+
+func newClient(t *testing.T) (*fakeserver.Server, *clients.Client) {
+	t.Helper()
+
+	server := fakeserver.New()
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	t.Setenv("KUBIYA_CONTROL_PLANE_BASE_URL", httpServer.URL)
+	client, err := clients.New("test-api-key")
+	require.NoError(t, err)
+
+	return server, client
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestAgentLifecycle(t *testing.T) {
+	ctx := context.Background()
+	server, client := newClient(t)
+
+	team, err := client.CreateTeam(ctx, &entities.TeamCreateRequest{Name: "platform"})
+	require.NoError(t, err)
+	assert.Equal(t, entities.TeamStatusActive, team.Status)
+
+	agent, err := client.CreateAgent(ctx, &entities.AgentCreateRequest{Name: "helper", TeamID: &team.ID})
+	require.NoError(t, err)
+	require.NotEmpty(t, agent.ID)
+	assert.Equal(t, entities.AgentStatusIdle, agent.Status)
+	assert.Equal(t, entities.RuntimeDefault, agent.Runtime)
+	require.NotNil(t, agent.CreatedAt)
+
+	updated, err := client.UpdateAgent(ctx, agent.ID, &entities.AgentUpdateRequest{Description: ptr("updated")})
+	require.NoError(t, err)
+	assert.Equal(t, "helper", updated.Name)
+	assert.Equal(t, "updated", *updated.Description)
+	assert.False(t, updated.UpdatedAt.Before(*agent.UpdatedAt))
+
+	stored, ok := server.Get(fakeserver.Agents, agent.ID)
+	require.True(t, ok)
+	assert.Equal(t, "updated", stored["description"])
+
+	require.NoError(t, client.DeleteAgent(ctx, agent.ID))
+	_, err = client.GetAgent(ctx, agent.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 404")
+}
+
+func TestValidation(t *testing.T) {
+	ctx := context.Background()
+	_, client := newClient(t)
+
+	_, err := client.CreateAgent(ctx, &entities.AgentCreateRequest{Name: "helper", TeamID: ptr("missing")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 422")
+
+	_, err = client.CreateProject(ctx, &entities.ProjectCreateRequest{Name: "one", Key: "ONE"})
+	require.NoError(t, err)
+	_, err = client.CreateProject(ctx, &entities.ProjectCreateRequest{Name: "two", Key: "ONE"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 409")
+
+	_, err = client.CreateJob(ctx, &entities.JobCreateRequest{Name: "nightly", TriggerType: "cron", PlanningMode: "on_the_fly", PromptTemplate: "run", ExecutorType: "auto"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cron_schedule is required")
+}
+
+func TestPaginationAndFilters(t *testing.T) {
+	ctx := context.Background()
+	_, client := newClient(t)
+
+	for i := 0; i < 250; i++ {
+		skillType := entities.SkillTypeShell
+		if i%2 == 0 {
+			skillType = entities.SkillTypePython
+		}
+		_, err := client.CreateSkill(ctx, &entities.SkillCreateRequest{Name: "skill", Type: skillType, Enabled: true})
+		require.NoError(t, err)
+	}
+
+	skills, err := client.ListSkills(ctx)
+	require.NoError(t, err)
+	assert.Len(t, skills, 250)
+
+	count := 0
+	for skill, err := range client.IterSkills(ctx, &clients.ListOptions{Filters: map[string]string{"type": "python"}}) {
+		require.NoError(t, err)
+		assert.Equal(t, entities.SkillTypePython, skill.Type)
+		count++
+	}
+	assert.Equal(t, 125, count)
+}
+
+func TestWorkerQueues(t *testing.T) {
+	ctx := context.Background()
+	_, client := newClient(t)
+
+	_, err := client.CreateWorkerQueue(ctx, "missing", &entities.WorkerQueueCreateRequest{Name: "default"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 404")
+
+	environment, err := client.CreateEnvironment(ctx, &entities.EnvironmentCreateRequest{Name: "production"})
+	require.NoError(t, err)
+
+	queue, err := client.CreateWorkerQueue(ctx, environment.ID, &entities.WorkerQueueCreateRequest{Name: "default"})
+	require.NoError(t, err)
+	assert.Equal(t, environment.ID, queue.EnvironmentID)
+	assert.Equal(t, 60, queue.HeartbeatInterval)
+
+	queues, err := client.ListWorkerQueues(ctx, environment.ID)
+	require.NoError(t, err)
+	assert.Len(t, queues, 1)
+
+	err = client.DeleteEnvironment(ctx, environment.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 409")
+
+	require.NoError(t, client.DeleteWorkerQueue(ctx, queue.ID))
+	require.NoError(t, client.DeleteEnvironment(ctx, environment.ID))
+}
+
+func TestPolicyVersions(t *testing.T) {
+	ctx := context.Background()
+	_, client := newClient(t)
+
+	policy, err := client.CreatePolicy(ctx, &entities.PolicyCreateRequest{Name: "guard", PolicyContent: "package a", Enabled: true})
+	require.NoError(t, err)
+
+	_, err = client.UpdatePolicy(ctx, policy.ID, &entities.PolicyUpdateRequest{Description: ptr("no new version")})
+	require.NoError(t, err)
+	_, err = client.UpdatePolicy(ctx, policy.ID, &entities.PolicyUpdateRequest{PolicyContent: ptr("package b")})
+	require.NoError(t, err)
+
+	versions, err := client.ListPolicyVersions(ctx, policy.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, int64(1), versions[0].Version)
+	assert.Equal(t, "package a", versions[0].PolicyContent)
+	assert.Equal(t, int64(2), versions[1].Version)
+	require.NotNil(t, versions[1].CreatedBy)
+	assert.Equal(t, fakeserver.UserEmail, *versions[1].CreatedBy)
+
+	version, err := client.GetPolicyVersion(ctx, policy.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "package a", version.PolicyContent)
+
+	_, err = client.GetPolicyVersion(ctx, policy.ID, 3)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 404")
+}
+
+func TestPolicyAssociations(t *testing.T) {
+	ctx := context.Background()
+	server, client := newClient(t)
+
+	policy, err := client.CreatePolicy(ctx, &entities.PolicyCreateRequest{Name: "guard", PolicyContent: "package a", Enabled: true})
+	require.NoError(t, err)
+	team, err := client.CreateTeam(ctx, &entities.TeamCreateRequest{Name: "platform"})
+	require.NoError(t, err)
+
+	_, err = client.CreatePolicyAssociation(ctx, &entities.PolicyAssociationCreateRequest{PolicyID: policy.ID, EntityType: entities.PolicyEntityAgent, EntityID: team.ID})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 422")
+
+	request := &entities.PolicyAssociationCreateRequest{PolicyID: policy.ID, EntityType: entities.PolicyEntityTeam, EntityID: team.ID}
+	association, err := client.CreatePolicyAssociation(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, team.ID, association.EntityID)
+
+	_, err = client.CreatePolicyAssociation(ctx, request)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 409")
+
+	count := 0
+	for found, err := range client.IterPolicyAssociations(ctx, &clients.ListOptions{Filters: map[string]string{"entity_id": team.ID}}) {
+		require.NoError(t, err)
+		assert.Equal(t, association.ID, found.ID)
+		count++
+	}
+	assert.Equal(t, 1, count)
+
+	// Deleting the team removes its attachments
+	require.NoError(t, client.DeleteTeam(ctx, team.ID))
+	assert.Empty(t, server.List(fakeserver.PolicyAssociations))
+	_, err = client.GetPolicyAssociation(ctx, association.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Policy association not found")
+}
+
+func TestSkillDefinitions(t *testing.T) {
+	ctx := context.Background()
+	server, client := newClient(t)
+
+	_, err := client.CreateSkill(ctx, &entities.SkillCreateRequest{Name: "tickets", Type: "jira"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 422")
+
+	server.RegisterSkillDefinition(fakeserver.Object{"type": "jira", "name": "Jira"})
+
+	definitions, err := client.ListSkillDefinitions(ctx)
+	require.NoError(t, err)
+	require.Len(t, definitions, 7)
+	assert.Equal(t, entities.SkillTypeShell, definitions[1].Type)
+	assert.Equal(t, "object", definitions[1].ConfigurationSchema["type"])
+	assert.False(t, definitions[1].Custom)
+	assert.Equal(t, entities.SkillType("jira"), definitions[6].Type)
+	assert.True(t, definitions[6].Custom)
+
+	_, err = client.CreateSkill(ctx, &entities.SkillCreateRequest{Name: "tickets", Type: "jira"})
+	require.NoError(t, err)
+}
+
+func TestTeamMembers(t *testing.T) {
+	ctx := context.Background()
+	server, client := newClient(t)
+
+	platform, err := client.CreateTeam(ctx, &entities.TeamCreateRequest{Name: "platform"})
+	require.NoError(t, err)
+	security, err := client.CreateTeam(ctx, &entities.TeamCreateRequest{Name: "security"})
+	require.NoError(t, err)
+	lead, err := client.CreateAgent(ctx, &entities.AgentCreateRequest{Name: "lead", TeamID: &platform.ID})
+	require.NoError(t, err)
+	helper, err := client.CreateAgent(ctx, &entities.AgentCreateRequest{Name: "helper"})
+	require.NoError(t, err)
+
+	leader := entities.TeamRoleLeader
+	member, err := client.UpdateTeamMember(ctx, platform.ID, lead.ID, &entities.TeamMemberRequest{Role: &leader})
+	require.NoError(t, err)
+	assert.Equal(t, entities.TeamRoleLeader, member.Role)
+
+	// A team has one leader
+	_, err = client.AddTeamMember(ctx, platform.ID, helper.ID, &entities.TeamMemberRequest{Role: &leader})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 409")
+
+	member, err = client.AddTeamMember(ctx, platform.ID, helper.ID, &entities.TeamMemberRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, entities.TeamRoleMember, member.Role)
+	assert.Equal(t, platform.ID, *member.TeamID)
+
+	team, err := client.GetTeam(ctx, platform.ID)
+	require.NoError(t, err)
+	require.Len(t, team.Agents, 2)
+	assert.Equal(t, "lead", team.Agents[0].Name)
+	assert.Equal(t, entities.TeamRoleLeader, team.Agents[0].Role)
+	assert.Equal(t, entities.TeamRoleMember, team.Agents[1].Role)
+
+	// Moving the leader to another team makes it a member there
+	member, err = client.AddTeamMember(ctx, security.ID, lead.ID, &entities.TeamMemberRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, entities.TeamRoleMember, member.Role)
+	team, err = client.GetTeam(ctx, platform.ID)
+	require.NoError(t, err)
+	require.Len(t, team.Agents, 1)
+	assert.Equal(t, helper.ID, team.Agents[0].ID)
+
+	require.NoError(t, client.RemoveTeamMember(ctx, platform.ID, helper.ID))
+	err = client.RemoveTeamMember(ctx, platform.ID, helper.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Agent is not a member of the team")
+
+	stored, ok := server.Get(fakeserver.Agents, helper.ID)
+	require.True(t, ok)
+	assert.NotContains(t, stored, "team_id")
+
+	// Deleting a team releases its agents
+	require.NoError(t, client.DeleteTeam(ctx, security.ID))
+	stored, ok = server.Get(fakeserver.Agents, lead.ID)
+	require.True(t, ok)
+	assert.NotContains(t, stored, "team_id")
+}
+
+func TestFaults(t *testing.T) {
+	ctx := context.Background()
+	server, client := newClient(t)
+
+	server.InjectFault(fakeserver.Fault{PathPrefix: "/api/v1/teams", StatusCode: http.StatusTooManyRequests, Times: 1})
+	_, err := client.ListTeams(ctx)
+	require.NoError(t, err, "a single 429 should be retried by the client")
+
+	server.InjectFault(fakeserver.Fault{Method: http.MethodPost, StatusCode: http.StatusInternalServerError, Times: 1})
+	_, err = client.CreateTeam(ctx, &entities.TeamCreateRequest{Name: "platform"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 500")
+	assert.Empty(t, server.List(fakeserver.Teams))
+
+	server.InjectFault(fakeserver.Fault{Latency: 50 * time.Millisecond, Times: 1})
+	start := time.Now()
+	_, err = client.CreateTeam(ctx, &entities.TeamCreateRequest{Name: "platform"})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestAuthentication(t *testing.T) {
+	ctx := context.Background()
+	server, client := newClient(t)
+	server.APIKey = "another-key"
+
+	_, err := client.ListAgents(ctx)
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "status 401"))
+}
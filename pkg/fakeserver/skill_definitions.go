@@ -0,0 +1,114 @@
+package fakeserver
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// builtinSkillDefinitions returns the skill types every organization has, with the JSON Schema their
+// configuration must match
+func builtinSkillDefinitions() []Object {
+	stringList := Object{"type": "array", "items": Object{"type": "string"}}
+	stringMap := Object{"type": "object", "additionalProperties": Object{"type": "string"}}
+	positive := Object{"type": "integer", "minimum": 1}
+
+	return []Object{
+		builtinSkillDefinition("file_system", "File System", "Read and write files", "folder",
+			Object{"read_only": false},
+			Object{
+				"base_dir":           Object{"type": "string"},
+				"read_only":          Object{"type": "boolean"},
+				"allowed_paths":      stringList,
+				"allowed_operations": stringList,
+				"max_file_size":      Object{"type": []interface{}{"integer", "string"}},
+			}),
+		builtinSkillDefinition("shell", "Shell", "Run shell commands", "terminal",
+			Object{"timeout": 300},
+			Object{
+				"allowed_commands": stringList,
+				"blocked_commands": stringList,
+				"timeout":          positive,
+				"working_dir":      Object{"type": "string"},
+				"shell":            Object{"type": "string"},
+				"env_vars":         stringMap,
+			}),
+		builtinSkillDefinition("docker", "Docker", "Run containers", "docker",
+			Object{"network": "bridge"},
+			Object{
+				"allowed_images":     stringList,
+				"allowed_registries": stringList,
+				"network":            Object{"type": "string"},
+				"image":              Object{"type": "string"},
+				"command":            stringList,
+				"volumes":            stringList,
+				"env_vars":           stringMap,
+				"memory_limit":       Object{"type": "string"},
+				"cpu_limit":          Object{"type": "string"},
+				"max_containers":     positive,
+			}),
+		builtinSkillDefinition("python", "Python", "Run Python scripts", "python",
+			Object{"python_version": "3.11"},
+			Object{
+				"python_version":  Object{"type": "string"},
+				"packages":        stringList,
+				"allowed_imports": stringList,
+				"entry_point":     Object{"type": "string"},
+				"timeout":         positive,
+				"env_vars":        stringMap,
+			}),
+		builtinSkillDefinition("file_generation", "File Generation", "Generate documents from templates", "file",
+			Object{},
+			nil),
+		builtinSkillDefinition("custom", "Custom", "Skill implemented by a custom handler", "puzzle",
+			Object{},
+			nil),
+	}
+}
+
+// builtinSkillDefinition returns a built-in skill definition. With properties, the configuration
+// schema rejects every other key; without, any configuration object is accepted.
+func builtinSkillDefinition(skillType, name, description, icon string, defaults, properties Object) Object {
+	schema := Object{"type": "object"}
+	if properties != nil {
+		schema["properties"] = properties
+		schema["additionalProperties"] = false
+	}
+
+	return Object{
+		"type":                  skillType,
+		"name":                  name,
+		"description":           description,
+		"icon":                  icon,
+		"custom":                false,
+		"default_configuration": defaults,
+		"configuration_schema":  schema,
+	}
+}
+
+// RegisterSkillDefinition adds a custom skill type, as an organization would. definition needs at
+// least a "type"; skills of that type can be created afterwards.
+func (s *Server) RegisterSkillDefinition(definition Object) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	definition = definition.clone()
+	definition["custom"] = true
+	s.definitions = append(s.definitions, definition)
+}
+
+func (s *Server) listSkillDefinitions(r *http.Request) (int, interface{}, *apiError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return page(s.definitions, r.URL.Query())
+}
+
+// skillTypes returns the built-in and registered skill types. Must be called with s.mu held.
+func (s *Server) skillTypes() []string {
+	types := make([]string, 0, len(s.definitions))
+	for _, definition := range s.definitions {
+		types = append(types, fmt.Sprint(definition["type"]))
+	}
+
+	return types
+}
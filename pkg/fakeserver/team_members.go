@@ -0,0 +1,141 @@
+package fakeserver
+
+import (
+	"net/http"
+)
+
+// teamRoles lists the roles an agent can have in its team. The role is stored on the agent as
+// team_role and returned as role in the agents of a team.
+var teamRoles = []string{"member", "leader"}
+
+// teamMembers returns the agents of a team in creation order, with their role. Must be called with
+// s.mu held.
+func (s *Server) teamMembers(teamID string) []Object {
+	members := []Object{}
+	for _, id := range s.order[Agents] {
+		if agent := s.objects[Agents][id]; agent["team_id"] == teamID {
+			members = append(members, teamMember(agent))
+		}
+	}
+
+	return members
+}
+
+// teamMember returns the representation of an agent in the agents of its team
+func teamMember(agent Object) Object {
+	member := agent.clone()
+	member["role"] = member["team_role"]
+	delete(member, "team_role")
+	return member
+}
+
+// setTeamRole sets the role of an agent in a team, refusing a second leader. Must be called with s.mu
+// held.
+func (s *Server) setTeamRole(teamID string, agent, input Object) *apiError {
+	// Without a role in the request the agent keeps its current one
+	current, _ := agent["team_role"].(string)
+	if current == "" {
+		current = "member"
+	}
+	if err := enum(input, "role", teamRoles, current); err != nil {
+		return err
+	}
+
+	if input["role"] == "leader" {
+		for _, other := range s.objects[Agents] {
+			if other["id"] != agent["id"] && other["team_id"] == teamID && other["team_role"] == "leader" {
+				return &apiError{status: http.StatusConflict, detail: "Team already has a leader"}
+			}
+		}
+	}
+
+	agent["team_role"] = input["role"]
+	return nil
+}
+
+func (s *Server) addTeamMember(teamID, agentID string, r *http.Request) (int, interface{}, *apiError) {
+	input, err := decodeObject(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.objects[Teams][teamID]; !ok {
+		return 0, nil, errNotFound(Teams.notFound())
+	}
+	current, ok := s.objects[Agents][agentID]
+	if !ok {
+		return 0, nil, errNotFound(Agents.notFound())
+	}
+
+	// An agent that moves in from another team starts over as a member
+	agent := current.clone()
+	if agent["team_id"] != teamID {
+		agent["team_id"] = teamID
+		delete(agent, "team_role")
+	}
+	if err := s.setTeamRole(teamID, agent, input); err != nil {
+		return 0, nil, err
+	}
+	agent["updated_at"] = s.timestamp()
+	s.objects[Agents][agentID] = agent
+
+	return http.StatusCreated, teamMember(agent), nil
+}
+
+func (s *Server) updateTeamMember(teamID, agentID string, r *http.Request) (int, interface{}, *apiError) {
+	input, err := decodeObject(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, apiErr := s.findTeamMember(teamID, agentID)
+	if apiErr != nil {
+		return 0, nil, apiErr
+	}
+
+	agent := current.clone()
+	if err := s.setTeamRole(teamID, agent, input); err != nil {
+		return 0, nil, err
+	}
+	agent["updated_at"] = s.timestamp()
+	s.objects[Agents][agentID] = agent
+
+	return http.StatusOK, teamMember(agent), nil
+}
+
+func (s *Server) removeTeamMember(teamID, agentID string) (int, interface{}, *apiError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	agent, err := s.findTeamMember(teamID, agentID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	delete(agent, "team_id")
+	delete(agent, "team_role")
+	agent["updated_at"] = s.timestamp()
+
+	return http.StatusNoContent, nil, nil
+}
+
+// findTeamMember returns the stored agent with the given ID if it is in the team. Must be called with
+// s.mu held.
+func (s *Server) findTeamMember(teamID, agentID string) (Object, *apiError) {
+	if _, ok := s.objects[Teams][teamID]; !ok {
+		return nil, errNotFound(Teams.notFound())
+	}
+
+	agent, ok := s.objects[Agents][agentID]
+	if !ok || agent["team_id"] != teamID {
+		return nil, errNotFound("Agent is not a member of the team")
+	}
+
+	return agent, nil
+}
@@ -7,25 +7,27 @@ import (
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/test/helpers"
 )
 
 // TestAgentDataSource tests the agent data source
 func TestAgentDataSource(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -46,5 +48,41 @@ func TestAgentDataSource(t *testing.T) {
 	runtime := terraform.Output(t, terraformOptions, "data_claude_code_runtime")
 	assert.Equal(t, "claude_code", runtime)
 
+	// Verify lookup by name resolves to the same agent
+	agentID := terraform.Output(t, terraformOptions, "full_agent_id")
+	byNameID := terraform.Output(t, terraformOptions, "data_by_name_id")
+	assert.Equal(t, agentID, byNameID)
+
 	t.Logf("✓ Agent data source test passed")
 }
+
+// TestAgentsDataSource tests filtering of the plural agents data source
+func TestAgentsDataSource(t *testing.T) {
+	t.Parallel()
+
+	apiKey := helpers.APIKey()
+	if apiKey == "" {
+		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
+	}
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/agents/list",
+		EnvVars: helpers.WithRecorder(t, map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
+			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                         os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":      "1",
+		}),
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	assert.Equal(t, "2", terraform.Output(t, terraformOptions, "by_team_count"))
+
+	claudeCodeNames := terraform.OutputList(t, terraformOptions, "claude_code_names")
+	assert.Equal(t, []string{"test-agents-list-claude-code"}, claudeCodeNames)
+
+	t.Logf("✓ Agents data source test passed")
+}
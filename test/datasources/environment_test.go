@@ -1,30 +1,36 @@
 package test
 
 import (
+	"context"
 	"os"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/pkg/controlplane"
+	"terraform-provider-kubiya-control-plane/pkg/fakeserver"
+	"terraform-provider-kubiya-control-plane/test/helpers"
 )
 
 // TestEnvironmentDataSource tests the environment data source
 func TestEnvironmentDataSource(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/environments",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -49,3 +55,49 @@ func TestEnvironmentDataSource(t *testing.T) {
 
 	t.Logf("✓ Environment data source test passed")
 }
+
+// TestEnvironmentsDataSource tests filtering of the plural environments data source against the fake
+// Control Plane
+func TestEnvironmentsDataSource(t *testing.T) {
+	t.Parallel()
+
+	server, baseURL := helpers.StartFakeServer(t)
+	client := helpers.FakeServerClient(t, baseURL)
+	ctx := context.Background()
+
+	for _, seed := range []struct {
+		name   string
+		tags   []string
+		status string
+	}{
+		{"test-environments-list-alpha", []string{"prod", "eu"}, "ready"},
+		{"test-environments-list-beta", []string{"prod"}, "inactive"},
+		{"test-environments-list-gamma", []string{"dev"}, "ready"},
+		{"other-environment", []string{"prod", "eu"}, "inactive"},
+	} {
+		environment, err := client.CreateEnvironment(ctx, &controlplane.EnvironmentCreateRequest{Name: seed.name, Tags: seed.tags})
+		require.NoError(t, err)
+		require.True(t, server.Modify(fakeserver.Environments, environment.ID, func(object fakeserver.Object) { object["status"] = seed.status }))
+	}
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/environments/list",
+		EnvVars: map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+			"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+			"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                          os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":       "1",
+		},
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	assert.ElementsMatch(t, []string{"test-environments-list-alpha", "test-environments-list-beta", "test-environments-list-gamma"}, terraform.OutputList(t, terraformOptions, "by_name_names"))
+	assert.Equal(t, []string{"test-environments-list-beta"}, terraform.OutputList(t, terraformOptions, "inactive_names"))
+	assert.ElementsMatch(t, []string{"test-environments-list-alpha", "test-environments-list-beta"}, terraform.OutputList(t, terraformOptions, "prod_names"))
+	assert.Equal(t, []string{"test-environments-list-alpha"}, terraform.OutputList(t, terraformOptions, "prod_eu_names"))
+	assert.Equal(t, "0", terraform.Output(t, terraformOptions, "none_count"))
+}
@@ -7,25 +7,27 @@ import (
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/test/helpers"
 )
 
 // TestJobDataSource tests the job data source
 func TestJobDataSource(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/jobs",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -64,19 +66,19 @@ func TestJobDataSource(t *testing.T) {
 func TestJobsDataSource(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/jobs",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
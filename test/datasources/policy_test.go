@@ -1,30 +1,35 @@
 package test
 
 import (
+	"context"
 	"os"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/pkg/controlplane"
+	"terraform-provider-kubiya-control-plane/test/helpers"
 )
 
 // TestPolicyDataSource tests the policy data source
 func TestPolicyDataSource(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/policies",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -49,3 +54,70 @@ func TestPolicyDataSource(t *testing.T) {
 
 	t.Logf("✓ Policy data source test passed")
 }
+
+// TestPolicyEvaluationDataSource tests local policy evaluation from content and from a stored policy
+func TestPolicyEvaluationDataSource(t *testing.T) {
+	t.Parallel()
+
+	_, baseURL := helpers.StartFakeServer(t)
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/policies/evaluation",
+		EnvVars: map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+			"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+			"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                          os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":       "1",
+		},
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	assert.Equal(t, "true", terraform.Output(t, terraformOptions, "allowed_result"))
+	assert.Equal(t, `["bob may not use kubectl"]`, terraform.Output(t, terraformOptions, "denied_result"))
+	assert.Equal(t, "false", terraform.Output(t, terraformOptions, "undefined_defined"))
+}
+
+// TestPoliciesDataSource tests filtering of the plural policies data source against the fake Control Plane
+func TestPoliciesDataSource(t *testing.T) {
+	t.Parallel()
+
+	_, baseURL := helpers.StartFakeServer(t)
+	client := helpers.FakeServerClient(t, baseURL)
+	ctx := context.Background()
+
+	const regoContent = "package test\n\ndefault allow := true\n"
+	for _, seed := range []controlplane.PolicyCreateRequest{
+		{Name: "test-policies-list-alpha", PolicyContent: regoContent, PolicyType: controlplane.PolicyTypeRego, Enabled: true, Tags: []string{"security"}},
+		{Name: "test-policies-list-beta", PolicyContent: `{"allow": true}`, PolicyType: controlplane.PolicyTypeJSON, Enabled: false, Tags: []string{"security", "cost"}},
+		{Name: "test-policies-list-gamma", PolicyContent: regoContent, PolicyType: controlplane.PolicyTypeRego, Enabled: false, Tags: []string{"cost"}},
+		{Name: "other-policy", PolicyContent: regoContent, PolicyType: controlplane.PolicyTypeRego, Enabled: false, Tags: []string{"security", "cost"}},
+	} {
+		_, err := client.CreatePolicy(ctx, &seed)
+		require.NoError(t, err)
+	}
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/policies/list",
+		EnvVars: map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+			"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+			"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                          os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":       "1",
+		},
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	assert.ElementsMatch(t, []string{"test-policies-list-alpha", "test-policies-list-beta", "test-policies-list-gamma"}, terraform.OutputList(t, terraformOptions, "by_name_names"))
+	assert.Equal(t, []string{"test-policies-list-beta"}, terraform.OutputList(t, terraformOptions, "json_names"))
+	assert.ElementsMatch(t, []string{"test-policies-list-beta", "test-policies-list-gamma"}, terraform.OutputList(t, terraformOptions, "disabled_names"))
+	assert.ElementsMatch(t, []string{"test-policies-list-alpha", "test-policies-list-beta"}, terraform.OutputList(t, terraformOptions, "security_names"))
+	assert.Equal(t, "0", terraform.Output(t, terraformOptions, "none_count"))
+}
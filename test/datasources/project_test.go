@@ -1,30 +1,36 @@
 package test
 
 import (
+	"context"
 	"os"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/pkg/controlplane"
+	"terraform-provider-kubiya-control-plane/pkg/fakeserver"
+	"terraform-provider-kubiya-control-plane/test/helpers"
 )
 
 // TestProjectDataSource tests the project data source
 func TestProjectDataSource(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/projects",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -55,3 +61,45 @@ func TestProjectDataSource(t *testing.T) {
 
 	t.Logf("✓ Project data source test passed")
 }
+
+// TestProjectsDataSource tests filtering of the plural projects data source against the fake Control Plane
+func TestProjectsDataSource(t *testing.T) {
+	t.Parallel()
+
+	server, baseURL := helpers.StartFakeServer(t)
+	client := helpers.FakeServerClient(t, baseURL)
+	ctx := context.Background()
+
+	for _, seed := range []struct {
+		name, key, visibility, status string
+	}{
+		{"test-projects-list-alpha", "TPLA", "private", "active"},
+		{"test-projects-list-beta", "TPLB", "org", "paused"},
+		{"test-projects-list-gamma", "TPLG", "org", "active"},
+		{"other-project", "OTHER", "org", "paused"},
+	} {
+		project, err := client.CreateProject(ctx, &controlplane.ProjectCreateRequest{Name: seed.name, Key: seed.key, Visibility: seed.visibility})
+		require.NoError(t, err)
+		require.True(t, server.Modify(fakeserver.Projects, project.ID, func(object fakeserver.Object) { object["status"] = seed.status }))
+	}
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/projects/list",
+		EnvVars: map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+			"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+			"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                          os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":       "1",
+		},
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	assert.ElementsMatch(t, []string{"test-projects-list-alpha", "test-projects-list-beta", "test-projects-list-gamma"}, terraform.OutputList(t, terraformOptions, "by_name_names"))
+	assert.Equal(t, []string{"test-projects-list-beta"}, terraform.OutputList(t, terraformOptions, "paused_names"))
+	assert.ElementsMatch(t, []string{"test-projects-list-beta", "test-projects-list-gamma"}, terraform.OutputList(t, terraformOptions, "org_names"))
+	assert.Equal(t, "0", terraform.Output(t, terraformOptions, "none_count"))
+}
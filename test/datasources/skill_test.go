@@ -1,30 +1,35 @@
 package test
 
 import (
+	"context"
 	"os"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/pkg/controlplane"
+	"terraform-provider-kubiya-control-plane/test/helpers"
 )
 
 // TestSkillDataSource tests the skill data source
 func TestSkillDataSource(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/skills",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -55,3 +60,42 @@ func TestSkillDataSource(t *testing.T) {
 
 	t.Logf("✓ Skill data source test passed")
 }
+
+// TestSkillsDataSource tests filtering of the plural skills data source against the fake Control Plane
+func TestSkillsDataSource(t *testing.T) {
+	t.Parallel()
+
+	_, baseURL := helpers.StartFakeServer(t)
+	client := helpers.FakeServerClient(t, baseURL)
+	ctx := context.Background()
+
+	for _, seed := range []controlplane.SkillCreateRequest{
+		{Name: "test-skills-list-alpha", Type: controlplane.SkillTypeShell, Enabled: true},
+		{Name: "test-skills-list-beta", Type: controlplane.SkillTypeFileSystem, Enabled: false},
+		{Name: "test-skills-list-gamma", Type: controlplane.SkillTypeShell, Enabled: false},
+		{Name: "other-skill", Type: controlplane.SkillTypeFileSystem, Enabled: true},
+	} {
+		_, err := client.CreateSkill(ctx, &seed)
+		require.NoError(t, err)
+	}
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/skills/list",
+		EnvVars: map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+			"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+			"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                          os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":       "1",
+		},
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	assert.ElementsMatch(t, []string{"test-skills-list-alpha", "test-skills-list-beta", "test-skills-list-gamma"}, terraform.OutputList(t, terraformOptions, "by_name_names"))
+	assert.ElementsMatch(t, []string{"test-skills-list-alpha", "test-skills-list-gamma"}, terraform.OutputList(t, terraformOptions, "shell_names"))
+	assert.Equal(t, []string{"test-skills-list-gamma"}, terraform.OutputList(t, terraformOptions, "disabled_shell_names"))
+	assert.Equal(t, "0", terraform.Output(t, terraformOptions, "none_count"))
+}
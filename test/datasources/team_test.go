@@ -1,30 +1,36 @@
 package test
 
 import (
+	"context"
 	"os"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/pkg/controlplane"
+	"terraform-provider-kubiya-control-plane/pkg/fakeserver"
+	"terraform-provider-kubiya-control-plane/test/helpers"
 )
 
 // TestTeamDataSource tests the team data source
 func TestTeamDataSource(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/teams",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -46,3 +52,48 @@ func TestTeamDataSource(t *testing.T) {
 
 	t.Logf("✓ Team data source test passed")
 }
+
+// TestTeamsDataSource tests filtering of the plural teams data source against the fake Control Plane
+func TestTeamsDataSource(t *testing.T) {
+	t.Parallel()
+
+	server, baseURL := helpers.StartFakeServer(t)
+	client := helpers.FakeServerClient(t, baseURL)
+	ctx := context.Background()
+
+	claudeCode := "claude_code"
+	for _, seed := range []struct {
+		name    string
+		runtime *string
+		status  string
+	}{
+		{"test-teams-list-alpha", nil, "active"},
+		{"test-teams-list-beta", &claudeCode, "inactive"},
+		{"test-teams-list-gamma", nil, "archived"},
+		{"other-team", &claudeCode, "inactive"},
+	} {
+		team, err := client.CreateTeam(ctx, &controlplane.TeamCreateRequest{Name: seed.name, Runtime: seed.runtime})
+		require.NoError(t, err)
+		require.True(t, server.Modify(fakeserver.Teams, team.ID, func(object fakeserver.Object) { object["status"] = seed.status }))
+	}
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/teams/list",
+		EnvVars: map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+			"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+			"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                          os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":       "1",
+		},
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	assert.ElementsMatch(t, []string{"test-teams-list-alpha", "test-teams-list-beta", "test-teams-list-gamma"}, terraform.OutputList(t, terraformOptions, "by_name_names"))
+	assert.Equal(t, []string{"test-teams-list-beta"}, terraform.OutputList(t, terraformOptions, "inactive_names"))
+	assert.Equal(t, []string{"test-teams-list-beta"}, terraform.OutputList(t, terraformOptions, "claude_code_names"))
+	assert.Equal(t, "0", terraform.Output(t, terraformOptions, "none_count"))
+}
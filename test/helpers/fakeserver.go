@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"terraform-provider-kubiya-control-plane/pkg/controlplane"
+	"terraform-provider-kubiya-control-plane/pkg/fakeserver"
+)
+
+// StartFakeServer starts an in-memory Control Plane API for the current test and returns it with its
+// base URL. Pass the URL to the provider as KUBIYA_CONTROL_PLANE_BASE_URL.
+func StartFakeServer(t *testing.T) (*fakeserver.Server, string) {
+	t.Helper()
+
+	server := fakeserver.New()
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	return server, httpServer.URL
+}
+
+// FakeServerClient returns an SDK client for the fake server at baseURL, for creating the objects a
+// configuration only reads
+func FakeServerClient(t *testing.T, baseURL string) *controlplane.Client {
+	t.Helper()
+
+	client, err := controlplane.New(controlplane.WithAPIKey("fake-api-key"), controlplane.WithBaseURL(baseURL))
+	if err != nil {
+		t.Fatalf("Failed to create the fake server client: %v", err)
+	}
+
+	return client
+}
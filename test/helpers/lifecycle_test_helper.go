@@ -27,11 +27,11 @@ func TestResourceUpdate(t *testing.T, config ResourceLifecycleTestConfig) {
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: config.TestDataDir,
-		EnvVars: map[string]string{
+		EnvVars: WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           getTFConfigFile(),
 			"HOME":                         os.Getenv("HOME"),
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -70,11 +70,11 @@ func TestResourceImport(t *testing.T, createDir, importDir, resourceType, resour
 	// Create resource
 	createOptions := &terraform.Options{
 		TerraformDir: createDir,
-		EnvVars: map[string]string{
+		EnvVars: WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           getTFConfigFile(),
 			"HOME":                         os.Getenv("HOME"),
-		},
+		}),
 	}
 
 	terraform.InitAndApply(t, createOptions)
@@ -88,11 +88,11 @@ func TestResourceImport(t *testing.T, createDir, importDir, resourceType, resour
 	// Import
 	importOptions := &terraform.Options{
 		TerraformDir: importDir,
-		EnvVars: map[string]string{
+		EnvVars: WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           getTFConfigFile(),
 			"HOME":                         os.Getenv("HOME"),
-		},
+		}),
 		Vars: map[string]interface{}{
 			fmt.Sprintf("%s_id", resourceType):   resourceID,
 			fmt.Sprintf("%s_name", resourceType): resourceNameValue,
@@ -122,11 +122,11 @@ func TestResourceStateRefresh(t *testing.T, testDataDir, resourceType string) {
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: testDataDir,
-		EnvVars: map[string]string{
+		EnvVars: WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           getTFConfigFile(),
 			"HOME":                         os.Getenv("HOME"),
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -155,11 +155,11 @@ func TestResourceComputedAttributes(t *testing.T, testDataDir, resourceType stri
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: testDataDir,
-		EnvVars: map[string]string{
+		EnvVars: WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           getTFConfigFile(),
 			"HOME":                         os.Getenv("HOME"),
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -196,7 +196,7 @@ func TestResourceComputedAttributes(t *testing.T, testDataDir, resourceType stri
 // getAPIKey retrieves the API key from environment
 func getAPIKey(t *testing.T) string {
 	t.Helper()
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
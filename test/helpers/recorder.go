@@ -0,0 +1,89 @@
+package helpers
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"terraform-provider-kubiya-control-plane/internal/clients"
+)
+
+// offlineAPIKey stands in for the API key when no request reaches the API
+const offlineAPIKey = "offline-api-key"
+
+// preparedCassettes tracks the tests whose cassette directory was already cleared or rewound
+var preparedCassettes sync.Map
+
+// RecorderMode returns the recorder mode selected with KUBIYA_CONTROL_PLANE_RECORDER_MODE. Without
+// it, tests replay their cassettes unless KUBIYA_CONTROL_PLANE_API_KEY is set.
+func RecorderMode() clients.RecorderMode {
+	if mode := os.Getenv("KUBIYA_CONTROL_PLANE_RECORDER_MODE"); mode != "" {
+		return clients.RecorderMode(mode)
+	}
+
+	if os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY") == "" {
+		return clients.RecorderReplay
+	}
+
+	return clients.RecorderOff
+}
+
+// APIKey returns the API key for tests, or a placeholder when the test does not talk to the API
+func APIKey() string {
+	if apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY"); apiKey != "" || RecorderMode() == clients.RecorderOff {
+		return apiKey
+	}
+
+	return offlineAPIKey
+}
+
+// WithRecorder adds the environment that makes the provider record or replay the API traffic of the
+// current test to envVars. Cassettes are kept in testdata/cassettes/<test name> next to the test. When
+// recording, cassettes left by a previous run are removed; recording needs KUBIYA_CONTROL_PLANE_API_KEY,
+// since cassettes stand in for the real API. When replaying, the test starts again from the first
+// cassette, and it is skipped if it has no cassettes. With the recorder off envVars is returned
+// unchanged.
+func WithRecorder(t *testing.T, envVars map[string]string) map[string]string {
+	t.Helper()
+
+	mode := RecorderMode()
+	if mode == clients.RecorderOff {
+		return envVars
+	}
+
+	dir, err := filepath.Abs(filepath.Join("testdata", "cassettes", t.Name()))
+	if err != nil {
+		t.Fatalf("Failed to resolve cassette directory: %v", err)
+	}
+
+	switch mode {
+	case clients.RecorderRecord:
+		if os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY") == "" {
+			t.Fatalf("Recording needs KUBIYA_CONTROL_PLANE_API_KEY; cassettes must capture the real API")
+		}
+	case clients.RecorderReplay:
+		if cassettes, _ := filepath.Glob(filepath.Join(dir, "*.json")); len(cassettes) == 0 {
+			t.Skipf("No cassettes in %s; set KUBIYA_CONTROL_PLANE_API_KEY to run against the API", dir)
+		}
+	}
+
+	if _, prepared := preparedCassettes.LoadOrStore(t.Name(), true); !prepared {
+		switch mode {
+		case clients.RecorderRecord:
+			err = os.RemoveAll(dir)
+		case clients.RecorderReplay:
+			err = clients.ResetReplay(dir)
+			t.Cleanup(func() { _ = clients.ResetReplay(dir) })
+		}
+		if err != nil {
+			t.Fatalf("Failed to prepare cassette directory %s: %v", dir, err)
+		}
+		t.Cleanup(func() { preparedCassettes.Delete(t.Name()) })
+	}
+
+	envVars["KUBIYA_CONTROL_PLANE_RECORDER_MODE"] = string(mode)
+	envVars["KUBIYA_CONTROL_PLANE_CASSETTE_DIR"] = dir
+
+	return envVars
+}
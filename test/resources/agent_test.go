@@ -7,25 +7,27 @@ import (
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/test/helpers"
 )
 
 // TestAgentBasic tests the basic agent resource lifecycle using the example
 func TestAgentBasic(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../examples/agent",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -40,19 +42,19 @@ func TestAgentBasic(t *testing.T) {
 func TestAgentMinimal(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping testdata test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -76,19 +78,19 @@ func TestAgentMinimal(t *testing.T) {
 func TestAgentFull(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping testdata test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents/full",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -121,19 +123,19 @@ func TestAgentFull(t *testing.T) {
 func TestAgentClaudeCode(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping testdata test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents/claude_code",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -160,19 +162,19 @@ func TestAgentClaudeCode(t *testing.T) {
 func TestAgentWithTeam(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping testdata test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents/with_team",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -200,19 +202,19 @@ func TestAgentWithTeam(t *testing.T) {
 func TestAgentComprehensive(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents/comprehensive",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -258,19 +260,19 @@ func TestAgentComprehensive(t *testing.T) {
 func TestAgentUpdate_Name(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -309,19 +311,19 @@ func TestAgentUpdate_Name(t *testing.T) {
 func TestAgentUpdate_MultipleFields(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents/update_multiple",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -362,19 +364,19 @@ func TestAgentUpdate_MultipleFields(t *testing.T) {
 func TestAgentUpdate_Runtime(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents/update_runtime",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -409,19 +411,19 @@ func TestAgentUpdate_Runtime(t *testing.T) {
 func TestAgentUpdate_TeamAssignment(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents/update_team",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -458,7 +460,7 @@ func TestAgentUpdate_TeamAssignment(t *testing.T) {
 func TestAgentImport(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
@@ -466,12 +468,12 @@ func TestAgentImport(t *testing.T) {
 	// First, create an agent outside of Terraform state
 	createOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	terraform.InitAndApply(t, createOptions)
@@ -485,12 +487,12 @@ func TestAgentImport(t *testing.T) {
 	// Now import it into a new state using import testdata
 	importOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents/import",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 		Vars: map[string]interface{}{
 			"agent_id":   agentID,
 			"agent_name": agentName,
@@ -517,11 +519,63 @@ func TestAgentImport(t *testing.T) {
 	t.Logf("✓ Agent import test passed: Successfully imported agent %s", agentID)
 }
 
+// TestAgentImport_ByName tests importing an existing agent using its name instead of its ID
+func TestAgentImport_ByName(t *testing.T) {
+	t.Parallel()
+
+	apiKey := helpers.APIKey()
+	if apiKey == "" {
+		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
+	}
+
+	createOptions := &terraform.Options{
+		TerraformDir: "../../testdata/agents/minimal",
+		EnvVars: helpers.WithRecorder(t, map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
+			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                         os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":      "1",
+		}),
+	}
+
+	terraform.InitAndApply(t, createOptions)
+	agentID := terraform.Output(t, createOptions, "agent_id")
+	agentName := terraform.Output(t, createOptions, "agent_name")
+	require.NotEmpty(t, agentID)
+
+	terraform.RunTerraformCommand(t, createOptions, "state", "rm", "controlplane_agent.minimal")
+
+	importOptions := &terraform.Options{
+		TerraformDir: "../../testdata/agents/import",
+		EnvVars: helpers.WithRecorder(t, map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
+			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                         os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":      "1",
+		}),
+		Vars: map[string]interface{}{
+			"agent_id":   agentID,
+			"agent_name": agentName,
+		},
+	}
+
+	defer terraform.Destroy(t, importOptions)
+
+	// Import using the agent name, which the provider resolves to the ID
+	terraform.Init(t, importOptions)
+	terraform.RunTerraformCommand(t, importOptions, "import", "controlplane_agent.imported", agentName)
+
+	importedID := terraform.Output(t, importOptions, "imported_agent_id")
+	assert.Equal(t, agentID, importedID, "Agent imported by name should resolve to the original ID")
+
+	t.Logf("✓ Agent import by name test passed: %s resolved to %s", agentName, agentID)
+}
+
 // TestAgentImport_FullConfiguration tests importing an agent with all fields
 func TestAgentImport_FullConfiguration(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
@@ -529,12 +583,12 @@ func TestAgentImport_FullConfiguration(t *testing.T) {
 	// Create a fully configured agent
 	createOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents/full",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	terraform.InitAndApply(t, createOptions)
@@ -553,12 +607,12 @@ func TestAgentImport_FullConfiguration(t *testing.T) {
 	// Import into new state
 	importOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents/import_full",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 		Vars: map[string]interface{}{
 			"agent_id": agentID,
 		},
@@ -596,19 +650,19 @@ func TestAgentImport_FullConfiguration(t *testing.T) {
 func TestAgentStateRefresh(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -640,19 +694,19 @@ func TestAgentStateRefresh(t *testing.T) {
 func TestAgentComputedAttributes(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/agents/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
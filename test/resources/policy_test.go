@@ -1,31 +1,38 @@
 package test
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/pkg/controlplane"
+	"terraform-provider-kubiya-control-plane/pkg/fakeserver"
+	"terraform-provider-kubiya-control-plane/test/helpers"
 )
 
 // TestPolicyBasic tests the basic policy resource lifecycle using the example
 func TestPolicyBasic(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../examples/policy",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -40,19 +47,19 @@ func TestPolicyBasic(t *testing.T) {
 func TestPolicyMinimal(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping testdata test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/policies/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -79,19 +86,19 @@ func TestPolicyMinimal(t *testing.T) {
 func TestPolicyFull(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping testdata test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/policies/full",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -121,19 +128,19 @@ func TestPolicyFull(t *testing.T) {
 func TestPolicyComprehensive(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/policies/comprehensive",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -163,19 +170,19 @@ func TestPolicyComprehensive(t *testing.T) {
 func TestPolicyUpdate_Fields(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/policies/update_fields",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -205,19 +212,19 @@ func TestPolicyUpdate_Fields(t *testing.T) {
 func TestPolicyImport(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	createOptions := &terraform.Options{
 		TerraformDir: "../../testdata/policies/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	terraform.InitAndApply(t, createOptions)
@@ -229,12 +236,12 @@ func TestPolicyImport(t *testing.T) {
 
 	importOptions := &terraform.Options{
 		TerraformDir: "../../testdata/policies/import",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 		Vars: map[string]interface{}{
 			"policy_id":   policyID,
 			"policy_name": policyName,
@@ -259,19 +266,19 @@ func TestPolicyImport(t *testing.T) {
 func TestPolicyStateRefresh(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/policies/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -288,3 +295,277 @@ func TestPolicyStateRefresh(t *testing.T) {
 
 	t.Logf("✓ State refresh test passed for policy %s", policyID)
 }
+
+// TestPolicyValidation tests that Rego and JSON errors in policy_content and failing Rego tests fail
+// the plan
+func TestPolicyValidation(t *testing.T) {
+	t.Parallel()
+
+	server, baseURL := helpers.StartFakeServer(t)
+
+	for _, tc := range []struct {
+		dir      string
+		expected []string
+	}{
+		{dir: "invalid_rego", expected: []string{"Invalid Rego Policy", "line 5"}},
+		{dir: "invalid_json", expected: []string{"Invalid JSON Policy", "line 3, column 1"}},
+		{dir: "failing_tests", expected: []string{"Failing Rego Test", "kubiya.tools_test.test_allows_marketing failed"}},
+		{dir: "invalid_attachment", expected: []string{"Invalid Target Type", `"workflow"`}},
+	} {
+		terraformOptions := &terraform.Options{
+			TerraformDir: "../../testdata/policies/" + tc.dir,
+			EnvVars: map[string]string{
+				"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+				"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+				"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+				"HOME":                          os.Getenv("HOME"),
+				"TF_SKIP_PROVIDER_VERIFY":       "1",
+			},
+		}
+
+		output, err := terraform.InitAndPlanE(t, terraformOptions)
+		require.Error(t, err, "%s must fail the plan", tc.dir)
+		for _, expected := range tc.expected {
+			assert.Contains(t, output, expected)
+		}
+	}
+
+	assert.Empty(t, server.List(fakeserver.Policies), "nothing is created when validation fails")
+}
+
+// TestPolicyBundle tests that a bundle creates, updates and deletes policies as its files change,
+// and leaves policies it does not own alone
+func TestPolicyBundle(t *testing.T) {
+	t.Parallel()
+
+	server, baseURL := helpers.StartFakeServer(t)
+	policyDir := t.TempDir()
+
+	client, err := controlplane.New(controlplane.WithAPIKey("fake-api-key"), controlplane.WithBaseURL(baseURL))
+	require.NoError(t, err)
+	_, err = client.CreatePolicy(context.Background(), &controlplane.PolicyCreateRequest{
+		Name:          "unrelated",
+		PolicyContent: "package unrelated",
+		PolicyType:    controlplane.PolicyTypeRego,
+	})
+	require.NoError(t, err)
+
+	writePolicy := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(policyDir, name), []byte(content), 0o644))
+	}
+	policyContents := func() map[string]string {
+		contents := map[string]string{}
+		for _, policy := range server.List(fakeserver.Policies) {
+			contents[policy["name"].(string)] = policy["policy_content"].(string)
+		}
+		return contents
+	}
+
+	writePolicy("deploy_hours.rego", "package kubiya.deploy\n\ndefault allow := true\n")
+	writePolicy("tool_access.rego", "package kubiya.tools\n\ndefault allow := false\n")
+	writePolicy("tool_access_test.rego", "package kubiya.tools_test\n\ntest_default if not data.kubiya.tools.allow\n")
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/policies/bundle",
+		Vars:         map[string]interface{}{"policy_dir": policyDir},
+		EnvVars: map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+			"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+			"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                          os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":       "1",
+		},
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	policyIDs := terraform.OutputMap(t, terraformOptions, "policy_ids")
+	assert.Len(t, policyIDs, 2)
+	assert.Len(t, policyContents(), 3)
+
+	// Change one file, remove another and add a third
+	writePolicy("deploy_hours.rego", "package kubiya.deploy\n\ndefault allow := false\n")
+	require.NoError(t, os.Remove(filepath.Join(policyDir, "tool_access.rego")))
+	writePolicy("cost_limits.rego", "package kubiya.cost\n\ndefault allow := true\n")
+
+	planOutput := terraform.Plan(t, terraformOptions)
+	assert.Contains(t, planOutput, `"deploy_hours"`)
+	assert.Contains(t, planOutput, `"tool_access"`)
+	assert.Contains(t, planOutput, `"cost_limits"`)
+
+	terraform.Apply(t, terraformOptions)
+
+	contents := policyContents()
+	assert.Equal(t, "package kubiya.deploy\n\ndefault allow := false\n", contents["deploy_hours"])
+	assert.Contains(t, contents, "cost_limits")
+	assert.NotContains(t, contents, "tool_access")
+	assert.Contains(t, contents, "unrelated")
+
+	exitCode := terraform.PlanExitCode(t, terraformOptions)
+	assert.Equal(t, 0, exitCode, "the bundle is in sync after apply")
+
+	terraform.Destroy(t, terraformOptions)
+	assert.Equal(t, map[string]string{"unrelated": "package unrelated"}, policyContents())
+}
+
+// TestPolicyPinning tests rolling a policy back to an earlier version and releasing the pin
+func TestPolicyPinning(t *testing.T) {
+	t.Parallel()
+
+	server, baseURL := helpers.StartFakeServer(t)
+
+	const first = "package kubiya.deploy\n\ndefault allow := true\n"
+	const second = "package kubiya.deploy\n\ndefault allow := false\n"
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/policies/pinning",
+		Vars:         map[string]interface{}{"policy_content": first},
+		EnvVars: map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+			"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+			"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                          os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":       "1",
+		},
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	// A new policy has no versions to pin
+	terraformOptions.Vars["pinned_version"] = 1
+	_, err := terraform.InitAndPlanE(t, terraformOptions)
+	require.Error(t, err)
+	delete(terraformOptions.Vars, "pinned_version")
+
+	terraform.InitAndApply(t, terraformOptions)
+	policyID := terraform.Output(t, terraformOptions, "policy_id")
+
+	terraformOptions.Vars["policy_content"] = second
+	terraform.Apply(t, terraformOptions)
+	assert.Equal(t, "2", terraform.Output(t, terraformOptions, "version"))
+
+	// Roll back to the first version while the configuration still holds the second
+	terraformOptions.Vars["pinned_version"] = 1
+	terraform.Apply(t, terraformOptions)
+
+	stored, ok := server.Get(fakeserver.Policies, policyID)
+	require.True(t, ok)
+	assert.Equal(t, first, stored["policy_content"])
+	assert.Equal(t, "3", terraform.Output(t, terraformOptions, "version"), "the rollback is recorded as a new version")
+	assert.Equal(t, 0, terraform.PlanExitCode(t, terraformOptions), "a pinned policy is in sync after apply")
+
+	terraform.Apply(t, terraformOptions)
+	assert.Equal(t, []string{"1", "2", "3"}, terraform.OutputList(t, terraformOptions, "versions"))
+
+	// Changes made outside Terraform are still detected while pinned
+	server.Modify(fakeserver.Policies, policyID, func(policy fakeserver.Object) {
+		policy["policy_content"] = "package kubiya.deploy\n"
+	})
+	assert.Equal(t, 2, terraform.PlanExitCode(t, terraformOptions))
+
+	// Releasing the pin restores the configured content
+	delete(terraformOptions.Vars, "pinned_version")
+	terraform.Apply(t, terraformOptions)
+
+	stored, ok = server.Get(fakeserver.Policies, policyID)
+	require.True(t, ok)
+	assert.Equal(t, second, stored["policy_content"])
+}
+
+// TestPolicyAttachment tests attaching a policy to a team, an environment and a project, and that
+// the project's own policy_ids are left alone
+func TestPolicyAttachment(t *testing.T) {
+	t.Parallel()
+
+	server, baseURL := helpers.StartFakeServer(t)
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/policies/attachment",
+		EnvVars: map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+			"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+			"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                          os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":       "1",
+		},
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	targets := map[string]bool{}
+	for _, association := range server.List(fakeserver.PolicyAssociations) {
+		targets[association["entity_type"].(string)] = true
+	}
+	assert.Equal(t, map[string]bool{"team": true, "environment": true, "project": true}, targets)
+
+	project, ok := server.Get(fakeserver.Projects, terraform.Output(t, terraformOptions, "project_id"))
+	require.True(t, ok)
+	assert.Empty(t, project["policy_ids"])
+
+	// Import by target and policy instead of the attachment ID
+	importID := fmt.Sprintf("team/%s/%s", terraform.Output(t, terraformOptions, "team_id"), terraform.Output(t, terraformOptions, "policy_id"))
+	terraform.RunTerraformCommand(t, terraformOptions, "state", "rm", "controlplane_policy_attachment.team")
+	terraform.RunTerraformCommand(t, terraformOptions, "import", "controlplane_policy_attachment.team", importID)
+
+	exitCode := terraform.PlanExitCode(t, terraformOptions)
+	assert.Equal(t, 0, exitCode, "attachments are in sync after apply and import")
+
+	terraform.Destroy(t, terraformOptions)
+	assert.Empty(t, server.List(fakeserver.PolicyAssociations))
+}
+
+// TestPolicyModifiedOutsideTerraform tests that an update is refused when the policy changed between
+// the refresh of the plan and the apply, unless skip_conflict_check is set
+func TestPolicyModifiedOutsideTerraform(t *testing.T) {
+	t.Parallel()
+
+	server, baseURL := helpers.StartFakeServer(t)
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/policies/conflict",
+		Vars:         map[string]interface{}{},
+		EnvVars: map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+			"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+			"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                          os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":       "1",
+		},
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+	policyID := terraform.Output(t, terraformOptions, "policy_id")
+
+	// The plan refreshes the policy, then someone edits it before the plan is applied
+	terraformOptions.Vars["description"] = "Changed by Terraform"
+	terraformOptions.PlanFilePath = "tfplan"
+	defer os.Remove(filepath.Join(terraformOptions.TerraformDir, terraformOptions.PlanFilePath))
+
+	terraform.Plan(t, terraformOptions)
+	require.True(t, server.Modify(fakeserver.Policies, policyID, func(policy fakeserver.Object) {
+		policy["description"] = "Changed in the UI"
+	}))
+
+	output, err := terraform.RunTerraformCommandE(t, terraformOptions, "apply", "-input=false", "-no-color", terraformOptions.PlanFilePath)
+	require.Error(t, err)
+	assert.Contains(t, output, "Resource Modified Outside Terraform")
+
+	policy, ok := server.Get(fakeserver.Policies, policyID)
+	require.True(t, ok)
+	assert.Equal(t, "Changed in the UI", policy["description"], "the change made outside Terraform must not be overwritten")
+
+	// With the check disabled, the apply overwrites the change
+	terraformOptions.Vars["skip_conflict_check"] = true
+	terraformOptions.PlanFilePath = ""
+	terraform.Apply(t, terraformOptions)
+
+	policy, ok = server.Get(fakeserver.Policies, policyID)
+	require.True(t, ok)
+	assert.Equal(t, "Changed by Terraform", policy["description"])
+}
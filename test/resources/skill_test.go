@@ -1,31 +1,35 @@
 package test
 
 import (
+	"encoding/json"
 	"os"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/pkg/fakeserver"
+	"terraform-provider-kubiya-control-plane/test/helpers"
 )
 
 // TestSkillBasic tests the basic skill resource lifecycle using the example
 func TestSkillBasic(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../examples/skill",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -40,19 +44,19 @@ func TestSkillBasic(t *testing.T) {
 func TestSkillMinimal(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping testdata test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/skills/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -79,19 +83,19 @@ func TestSkillMinimal(t *testing.T) {
 func TestSkillFull(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping testdata test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/skills/full",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -124,19 +128,19 @@ func TestSkillFull(t *testing.T) {
 func TestSkillComprehensive(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/skills/comprehensive",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -166,19 +170,19 @@ func TestSkillComprehensive(t *testing.T) {
 func TestSkillUpdate_Fields(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/skills/update_fields",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -208,19 +212,19 @@ func TestSkillUpdate_Fields(t *testing.T) {
 func TestSkillImport(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	createOptions := &terraform.Options{
 		TerraformDir: "../../testdata/skills/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	terraform.InitAndApply(t, createOptions)
@@ -232,12 +236,12 @@ func TestSkillImport(t *testing.T) {
 
 	importOptions := &terraform.Options{
 		TerraformDir: "../../testdata/skills/import",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 		Vars: map[string]interface{}{
 			"skill_id":   skillID,
 			"skill_name": skillName,
@@ -262,19 +266,19 @@ func TestSkillImport(t *testing.T) {
 func TestSkillStateRefresh(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/skills/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -291,3 +295,191 @@ func TestSkillStateRefresh(t *testing.T) {
 
 	t.Logf("✓ State refresh test passed for skill %s", skillID)
 }
+
+// TestSkillTypedConfiguration tests that typed configuration blocks are sent as configuration keys
+func TestSkillTypedConfiguration(t *testing.T) {
+	t.Parallel()
+
+	server, baseURL := helpers.StartFakeServer(t)
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/skills/typed",
+		EnvVars: map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+			"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+			"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                          os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":       "1",
+		},
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	shell, ok := server.Get(fakeserver.Skills, terraform.Output(t, terraformOptions, "shell_skill_id"))
+	require.True(t, ok)
+	assert.Equal(t, map[string]interface{}{
+		"allowed_commands": []interface{}{"kubectl", "helm"},
+		"blocked_commands": []interface{}{"rm"},
+		"timeout":          json.Number("300"),
+	}, shell["configuration"])
+
+	fileSystem, ok := server.Get(fakeserver.Skills, terraform.Output(t, terraformOptions, "file_system_skill_id"))
+	require.True(t, ok)
+	assert.Equal(t, map[string]interface{}{
+		"max_file_size": json.Number("10485760"),
+		"base_dir":      "/app",
+		"read_only":     true,
+		"allowed_paths": []interface{}{"/app", "/tmp"},
+	}, fileSystem["configuration"])
+
+	exitCode := terraform.PlanExitCode(t, terraformOptions)
+	assert.Equal(t, 0, exitCode, "typed blocks are in sync after apply")
+}
+
+// TestSkillValidation tests that misconfigured skills fail the plan
+func TestSkillValidation(t *testing.T) {
+	t.Parallel()
+
+	server, baseURL := helpers.StartFakeServer(t)
+
+	for _, tc := range []struct {
+		dir      string
+		expected []string
+	}{
+		{dir: "invalid_block", expected: []string{"Invalid Skill Configuration", `The docker block can only be set when type is "docker"`}},
+		{dir: "invalid_configuration", expected: []string{"Invalid Skill Configuration", "additional properties 'bogus' not allowed", "timeout: minimum: got 0, want 1"}},
+		{dir: "unsupported_type", expected: []string{"Unsupported Skill Type", `type "jira" is not a skill type the Control Plane supports`}},
+	} {
+		terraformOptions := &terraform.Options{
+			TerraformDir: "../../testdata/skills/" + tc.dir,
+			EnvVars: map[string]string{
+				"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+				"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+				"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+				"HOME":                          os.Getenv("HOME"),
+				"TF_SKIP_PROVIDER_VERIFY":       "1",
+			},
+		}
+
+		output, err := terraform.InitAndPlanE(t, terraformOptions)
+		require.Error(t, err, "%s must fail the plan", tc.dir)
+		for _, expected := range tc.expected {
+			assert.Contains(t, output, expected)
+		}
+	}
+
+	assert.Empty(t, server.List(fakeserver.Skills), "nothing is created when validation fails")
+}
+
+// TestSkillDefinitions tests listing skill definitions, including a custom type registered by the
+// organization, and creating a skill of that type
+func TestSkillDefinitions(t *testing.T) {
+	t.Parallel()
+
+	server, baseURL := helpers.StartFakeServer(t)
+	server.RegisterSkillDefinition(fakeserver.Object{
+		"type":        "jira",
+		"name":        "Jira",
+		"description": "Create and update Jira issues",
+		"configuration_schema": fakeserver.Object{
+			"type":                 "object",
+			"properties":           fakeserver.Object{"project": fakeserver.Object{"type": "string"}},
+			"additionalProperties": false,
+		},
+	})
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/skills/definitions",
+		EnvVars: map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+			"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+			"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                          os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":       "1",
+		},
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	types := terraform.OutputList(t, terraformOptions, "types")
+	assert.Contains(t, types, "shell")
+	assert.Contains(t, types, "jira")
+	assert.Equal(t, []string{"jira"}, terraform.OutputList(t, terraformOptions, "custom_types"))
+
+	var shellSchema map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(terraform.Output(t, terraformOptions, "shell_schema")), &shellSchema))
+	assert.Equal(t, false, shellSchema["additionalProperties"])
+
+	assert.Len(t, server.List(fakeserver.Skills), 1)
+}
+
+// TestSkillToggleAndReplace tests that enabled defaults to true and toggles in place with clean
+// plans, and that changing type replaces the skill
+func TestSkillToggleAndReplace(t *testing.T) {
+	t.Parallel()
+
+	server, baseURL := helpers.StartFakeServer(t)
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/skills/toggle",
+		Vars:         map[string]interface{}{},
+		EnvVars: map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+			"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+			"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                          os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":       "1",
+		},
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	skillID := terraform.Output(t, terraformOptions, "skill_id")
+	assert.Equal(t, "true", terraform.Output(t, terraformOptions, "enabled"))
+	assert.Equal(t, 0, terraform.PlanExitCode(t, terraformOptions), "enabled must default to true without a diff")
+
+	// Toggle off and back on, in place
+	for _, enabled := range []interface{}{false, nil} {
+		if enabled == nil {
+			delete(terraformOptions.Vars, "enabled")
+		} else {
+			terraformOptions.Vars["enabled"] = enabled
+		}
+		terraform.Apply(t, terraformOptions)
+		assert.Equal(t, skillID, terraform.Output(t, terraformOptions, "skill_id"))
+		assert.Equal(t, 0, terraform.PlanExitCode(t, terraformOptions))
+
+		stored, ok := server.Get(fakeserver.Skills, skillID)
+		require.True(t, ok)
+		assert.Equal(t, enabled != false, stored["enabled"])
+	}
+
+	// A type changed outside Terraform is detected and plans a replacement
+	server.Modify(fakeserver.Skills, skillID, func(skill fakeserver.Object) {
+		skill["type"] = "docker"
+	})
+	output := terraform.Plan(t, terraformOptions)
+	assert.Contains(t, output, "1 to add, 0 to change, 1 to destroy")
+	server.Modify(fakeserver.Skills, skillID, func(skill fakeserver.Object) {
+		skill["type"] = "shell"
+	})
+
+	// Changing type replaces the skill
+	terraformOptions.Vars["skill_type"] = "python"
+	terraform.Apply(t, terraformOptions)
+
+	replacedID := terraform.Output(t, terraformOptions, "skill_id")
+	assert.NotEqual(t, skillID, replacedID)
+	_, ok := server.Get(fakeserver.Skills, skillID)
+	assert.False(t, ok, "the old skill must be deleted")
+
+	stored, ok := server.Get(fakeserver.Skills, replacedID)
+	require.True(t, ok)
+	assert.Equal(t, "python", stored["type"])
+}
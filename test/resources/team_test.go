@@ -2,30 +2,34 @@ package test
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/pkg/fakeserver"
+	"terraform-provider-kubiya-control-plane/test/helpers"
 )
 
 // TestTeamBasic tests the basic team resource lifecycle using the example
 func TestTeamBasic(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../examples/team",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -40,19 +44,19 @@ func TestTeamBasic(t *testing.T) {
 func TestTeamMinimal(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping testdata test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/teams/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -76,19 +80,19 @@ func TestTeamMinimal(t *testing.T) {
 func TestTeamFull(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping testdata test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/teams/full",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -124,19 +128,19 @@ func TestTeamFull(t *testing.T) {
 func TestTeamClaudeCode(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping testdata test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/teams/claude_code",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -160,19 +164,19 @@ func TestTeamClaudeCode(t *testing.T) {
 func TestTeamComprehensive(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/teams/comprehensive",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -223,19 +227,19 @@ func TestTeamComprehensive(t *testing.T) {
 func TestTeamUpdate_Name(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/teams/update_name",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -267,19 +271,19 @@ func TestTeamUpdate_Name(t *testing.T) {
 func TestTeamUpdate_Status(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/teams/update_status",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -313,19 +317,19 @@ func TestTeamUpdate_Status(t *testing.T) {
 func TestTeamUpdate_Runtime(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/teams/update_runtime",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -358,19 +362,19 @@ func TestTeamUpdate_Runtime(t *testing.T) {
 func TestTeamUpdate_MultipleFields(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/teams/update_multiple",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -414,7 +418,7 @@ func TestTeamUpdate_MultipleFields(t *testing.T) {
 func TestTeamImport(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
@@ -422,12 +426,12 @@ func TestTeamImport(t *testing.T) {
 	// First, create a team
 	createOptions := &terraform.Options{
 		TerraformDir: "../../testdata/teams/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	terraform.InitAndApply(t, createOptions)
@@ -441,12 +445,12 @@ func TestTeamImport(t *testing.T) {
 	// Import into new state
 	importOptions := &terraform.Options{
 		TerraformDir: "../../testdata/teams/import",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 		Vars: map[string]interface{}{
 			"team_id":   teamID,
 			"team_name": teamName,
@@ -472,7 +476,7 @@ func TestTeamImport(t *testing.T) {
 func TestTeamImport_FullConfiguration(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
@@ -480,12 +484,12 @@ func TestTeamImport_FullConfiguration(t *testing.T) {
 	// Create a fully configured team
 	createOptions := &terraform.Options{
 		TerraformDir: "../../testdata/teams/full",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	terraform.InitAndApply(t, createOptions)
@@ -502,12 +506,12 @@ func TestTeamImport_FullConfiguration(t *testing.T) {
 	// Import into new state
 	importOptions := &terraform.Options{
 		TerraformDir: "../../testdata/teams/import_full",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 		Vars: map[string]interface{}{
 			"team_id": teamID,
 		},
@@ -542,19 +546,19 @@ func TestTeamImport_FullConfiguration(t *testing.T) {
 func TestTeamStateRefresh(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/teams/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -582,19 +586,19 @@ func TestTeamStateRefresh(t *testing.T) {
 func TestTeamComputedAttributes(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/teams/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -611,3 +615,155 @@ func TestTeamComputedAttributes(t *testing.T) {
 
 	t.Logf("✓ Computed attributes test passed: created_at=%s", createdAt)
 }
+
+// TestTeamMembers tests managing the agents of a team with controlplane_team_members and
+// controlplane_team_member, handing over the leader role, drift and import
+func TestTeamMembers(t *testing.T) {
+	t.Parallel()
+
+	server, baseURL := helpers.StartFakeServer(t)
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/teams/members",
+		Vars:         map[string]interface{}{},
+		EnvVars: map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+			"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+			"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                          os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":       "1",
+		},
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	assert.Equal(t, map[string]string{
+		"test-team-members-alpha": "leader",
+		"test-team-members-beta":  "member",
+	}, terraform.OutputMap(t, terraformOptions, "platform_agents"))
+	assert.Equal(t, "member", terraform.Output(t, terraformOptions, "gamma_role"))
+	assert.Equal(t, 0, terraform.PlanExitCode(t, terraformOptions), "agents must not show their new team as drift")
+
+	agentIDs := terraform.OutputMap(t, terraformOptions, "agent_ids")
+	securityID := terraform.Output(t, terraformOptions, "security_team_id")
+	gamma, ok := server.Get(fakeserver.Agents, agentIDs["gamma"])
+	require.True(t, ok)
+	assert.Equal(t, securityID, gamma["team_id"])
+
+	// Hand the leader role over in one apply
+	terraformOptions.Vars["platform_leader"] = "beta"
+	terraform.Apply(t, terraformOptions)
+	assert.Equal(t, map[string]string{
+		"test-team-members-alpha": "member",
+		"test-team-members-beta":  "leader",
+	}, terraform.OutputMap(t, terraformOptions, "platform_agents"))
+	assert.Equal(t, 0, terraform.PlanExitCode(t, terraformOptions))
+
+	// An agent removed from the team outside Terraform is added back
+	server.Modify(fakeserver.Agents, agentIDs["alpha"], func(agent fakeserver.Object) {
+		delete(agent, "team_id")
+		delete(agent, "team_role")
+	})
+	server.Modify(fakeserver.Agents, agentIDs["gamma"], func(agent fakeserver.Object) {
+		delete(agent, "team_id")
+		delete(agent, "team_role")
+	})
+	assert.Equal(t, 2, terraform.PlanExitCode(t, terraformOptions))
+	terraform.Apply(t, terraformOptions)
+	assert.Len(t, terraform.OutputMap(t, terraformOptions, "platform_agents"), 2)
+	assert.Equal(t, 0, terraform.PlanExitCode(t, terraformOptions))
+
+	// Removing an agent from the list removes it from the team
+	terraformOptions.Vars["platform_members"] = []string{"beta"}
+	terraform.Apply(t, terraformOptions)
+	assert.Equal(t, map[string]string{"test-team-members-beta": "leader"}, terraform.OutputMap(t, terraformOptions, "platform_agents"))
+	alpha, ok := server.Get(fakeserver.Agents, agentIDs["alpha"])
+	require.True(t, ok)
+	assert.NotContains(t, alpha, "team_id")
+
+	// Import by team name, and by team and agent name
+	terraform.RunTerraformCommand(t, terraformOptions, "state", "rm", "controlplane_team_members.platform", "controlplane_team_member.gamma")
+	terraform.RunTerraformCommand(t, terraformOptions, "import", "controlplane_team_members.platform", "test-team-members-platform")
+	terraform.RunTerraformCommand(t, terraformOptions, "import", "controlplane_team_member.gamma", "test-team-members-security/test-team-members-gamma")
+	assert.Equal(t, 0, terraform.PlanExitCode(t, terraformOptions), "imported memberships must match the configuration")
+
+	// Destroying the memberships releases the agents
+	terraform.Destroy(t, terraformOptions)
+	for _, agent := range server.List(fakeserver.Agents) {
+		assert.NotContains(t, agent, "team_id")
+	}
+}
+
+// TestTeamMembersWithAgentUpdate tests that an agent moved by team_members can be updated in the same
+// apply: the provider's own membership write bumps the agent's updated_at, which is not a conflict
+func TestTeamMembersWithAgentUpdate(t *testing.T) {
+	t.Parallel()
+
+	server, baseURL := helpers.StartFakeServer(t)
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/teams/members_agent_update",
+		Vars:         map[string]interface{}{},
+		EnvVars: map[string]string{
+			"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+			"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+			"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                          os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY":       "1",
+		},
+	}
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+	agentID := terraform.Output(t, terraformOptions, "agent_id")
+
+	// The membership is written before the agent update, in one apply
+	terraformOptions.Vars["agent_id"] = agentID
+	terraformOptions.Vars["description"] = "After the team change"
+	terraform.Apply(t, terraformOptions)
+
+	agent, ok := server.Get(fakeserver.Agents, agentID)
+	require.True(t, ok)
+	assert.Equal(t, terraform.Output(t, terraformOptions, "platform_team_id"), agent["team_id"])
+	assert.Equal(t, "After the team change", agent["description"])
+	assert.Equal(t, 0, terraform.PlanExitCode(t, terraformOptions), "the refreshed team must not show as drift")
+
+	// A change made outside Terraform between plan and apply is still caught
+	terraformOptions.Vars["description"] = "Final"
+	terraformOptions.PlanFilePath = "tfplan"
+	defer os.Remove(filepath.Join(terraformOptions.TerraformDir, terraformOptions.PlanFilePath))
+
+	terraform.Plan(t, terraformOptions)
+	require.True(t, server.Modify(fakeserver.Agents, agentID, func(agent fakeserver.Object) {
+		agent["description"] = "Changed in the UI"
+	}))
+
+	output, err := terraform.RunTerraformCommandE(t, terraformOptions, "apply", "-input=false", "-no-color", terraformOptions.PlanFilePath)
+	require.Error(t, err)
+	assert.Contains(t, output, "Resource Modified Outside Terraform")
+	terraformOptions.PlanFilePath = ""
+}
+
+// TestTeamMembersValidation tests that invalid roles and a second leader fail validation
+func TestTeamMembersValidation(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../../testdata/teams/invalid_members",
+		EnvVars: map[string]string{
+			"TF_CLI_CONFIG_FILE":      os.Getenv("TF_CLI_CONFIG_FILE"),
+			"HOME":                    os.Getenv("HOME"),
+			"TF_SKIP_PROVIDER_VERIFY": "1",
+		},
+	}
+
+	terraform.Init(t, terraformOptions)
+	output, err := terraform.ValidateE(t, terraformOptions)
+	require.Error(t, err)
+	assert.Contains(t, output, "Multiple Team Leaders")
+	assert.Contains(t, output, "Invalid Team Role")
+	assert.Contains(t, output, `role must be one of "member", "leader", got "owner"`)
+}
@@ -1,31 +1,37 @@
 package test
 
 import (
+	"encoding/json"
+	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"terraform-provider-kubiya-control-plane/pkg/fakeserver"
+	"terraform-provider-kubiya-control-plane/test/helpers"
 )
 
 // TestWorkerQueueBasic tests the basic worker queue resource lifecycle using the example
 func TestWorkerQueueBasic(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../examples/worker_queue",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -40,19 +46,19 @@ func TestWorkerQueueBasic(t *testing.T) {
 func TestWorkerQueueMinimal(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping testdata test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/workers/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -79,19 +85,19 @@ func TestWorkerQueueMinimal(t *testing.T) {
 func TestWorkerQueueFull(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping testdata test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/workers/full",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -124,19 +130,19 @@ func TestWorkerQueueFull(t *testing.T) {
 func TestWorkerQueueComprehensive(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Fatal("KUBIYA_CONTROL_PLANE_API_KEY environment variable is not set")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/workers/comprehensive",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -166,19 +172,19 @@ func TestWorkerQueueComprehensive(t *testing.T) {
 func TestWorkerQueueUpdate_Fields(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/worker_queues/update_fields",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -208,19 +214,19 @@ func TestWorkerQueueUpdate_Fields(t *testing.T) {
 func TestWorkerQueueImport(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	createOptions := &terraform.Options{
 		TerraformDir: "../../testdata/workers/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	terraform.InitAndApply(t, createOptions)
@@ -232,12 +238,12 @@ func TestWorkerQueueImport(t *testing.T) {
 
 	importOptions := &terraform.Options{
 		TerraformDir: "../../testdata/worker_queues/import",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 		Vars: map[string]interface{}{
 			"worker_queue_id":   workerQueueID,
 			"worker_queue_name": workerQueueName,
@@ -262,19 +268,19 @@ func TestWorkerQueueImport(t *testing.T) {
 func TestWorkerQueueStateRefresh(t *testing.T) {
 	t.Parallel()
 
-	apiKey := os.Getenv("KUBIYA_CONTROL_PLANE_API_KEY")
+	apiKey := helpers.APIKey()
 	if apiKey == "" {
 		t.Skip("KUBIYA_CONTROL_PLANE_API_KEY not set, skipping test")
 	}
 
 	terraformOptions := &terraform.Options{
 		TerraformDir: "../../testdata/workers/minimal",
-		EnvVars: map[string]string{
+		EnvVars: helpers.WithRecorder(t, map[string]string{
 			"KUBIYA_CONTROL_PLANE_API_KEY": apiKey,
 			"TF_CLI_CONFIG_FILE":           os.Getenv("TF_CLI_CONFIG_FILE"),
 			"HOME":                         os.Getenv("HOME"),
 			"TF_SKIP_PROVIDER_VERIFY":      "1",
-		},
+		}),
 	}
 
 	defer terraform.Destroy(t, terraformOptions)
@@ -291,3 +297,98 @@ func TestWorkerQueueStateRefresh(t *testing.T) {
 
 	t.Logf("✓ State refresh test passed for worker queue %s", workerQueueID)
 }
+
+// TestWorkerQueueDrain tests that destroy pauses a queue with active workers and waits for them to
+// detach, deletes it anyway when drain_timeout expires, and skips draining with force_destroy
+func TestWorkerQueueDrain(t *testing.T) {
+	t.Parallel()
+
+	// applyWithActiveWorkers creates the queue on a new fake server and attaches two workers to it
+	applyWithActiveWorkers := func(t *testing.T, vars map[string]interface{}) (*fakeserver.Server, *terraform.Options, string) {
+		server, baseURL := helpers.StartFakeServer(t)
+
+		terraformOptions := &terraform.Options{
+			TerraformDir: "../../testdata/worker_queues/drain",
+			Vars:         vars,
+			EnvVars: map[string]string{
+				"KUBIYA_CONTROL_PLANE_API_KEY":  "fake-api-key",
+				"KUBIYA_CONTROL_PLANE_BASE_URL": baseURL,
+				"TF_CLI_CONFIG_FILE":            os.Getenv("TF_CLI_CONFIG_FILE"),
+				"HOME":                          os.Getenv("HOME"),
+				"TF_SKIP_PROVIDER_VERIFY":       "1",
+			},
+		}
+
+		terraform.InitAndApply(t, terraformOptions)
+
+		queueID := terraform.Output(t, terraformOptions, "worker_queue_id")
+		require.True(t, server.Modify(fakeserver.WorkerQueues, queueID, func(queue fakeserver.Object) {
+			queue["active_workers"] = json.Number("2")
+		}))
+
+		return server, terraformOptions, queueID
+	}
+
+	// waitForPause reports on the returned channel whether the queue was paused before it was deleted.
+	// With detach set, the workers detach as soon as the queue is paused.
+	waitForPause := func(server *fakeserver.Server, queueID string, detach bool) <-chan bool {
+		paused := make(chan bool, 1)
+		go func() {
+			for {
+				queue, ok := server.Get(fakeserver.WorkerQueues, queueID)
+				if !ok {
+					paused <- false
+					return
+				}
+				if queue["status"] == "paused" {
+					if detach {
+						server.Modify(fakeserver.WorkerQueues, queueID, func(queue fakeserver.Object) {
+							queue["active_workers"] = json.Number("0")
+						})
+					}
+					paused <- true
+					return
+				}
+				time.Sleep(50 * time.Millisecond)
+			}
+		}()
+
+		return paused
+	}
+
+	// The subtests share the configuration directory, so they run one after the other
+	t.Run("PauseThenDrain", func(t *testing.T) {
+		server, terraformOptions, queueID := applyWithActiveWorkers(t, map[string]interface{}{})
+		paused := waitForPause(server, queueID, true)
+
+		terraform.Destroy(t, terraformOptions)
+
+		assert.True(t, <-paused, "destroy must pause the queue before deleting it")
+		_, ok := server.Get(fakeserver.WorkerQueues, queueID)
+		assert.False(t, ok)
+	})
+
+	t.Run("TimeoutExpiry", func(t *testing.T) {
+		server, terraformOptions, queueID := applyWithActiveWorkers(t, map[string]interface{}{"drain_timeout": 1})
+		paused := waitForPause(server, queueID, false)
+
+		// The workers never detach: destroy logs a warning and deletes the queue once the timeout expires
+		terraform.Destroy(t, terraformOptions)
+
+		assert.True(t, <-paused, "destroy must pause the queue before deleting it")
+		_, ok := server.Get(fakeserver.WorkerQueues, queueID)
+		assert.False(t, ok, "the queue must be deleted with active workers after the drain timeout")
+	})
+
+	t.Run("ForceDestroy", func(t *testing.T) {
+		server, terraformOptions, queueID := applyWithActiveWorkers(t, map[string]interface{}{"force_destroy": true})
+
+		// Pausing the queue would fail, so destroy only succeeds if it skips the drain
+		server.InjectFault(fakeserver.Fault{Method: http.MethodPatch, PathPrefix: "/api/v1/worker-queues", StatusCode: http.StatusInternalServerError})
+
+		terraform.Destroy(t, terraformOptions)
+
+		_, ok := server.Get(fakeserver.WorkerQueues, queueID)
+		assert.False(t, ok)
+	})
+}
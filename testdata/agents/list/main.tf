@@ -0,0 +1,45 @@
+provider "controlplane" {
+  # Configuration via environment variables:
+  # KUBIYA_CONTROL_PLANE_API_KEY
+  # KUBIYA_CONTROL_PLANE_BASE_URL (optional, defaults to https://control-plane.kubiya.ai)
+}
+
+resource "controlplane_team" "list" {
+  name = "test-team-agents-list"
+}
+
+resource "controlplane_agent" "default_runtime" {
+  name    = "test-agents-list-default"
+  runtime = "default"
+  team_id = controlplane_team.list.id
+}
+
+resource "controlplane_agent" "claude_code" {
+  name    = "test-agents-list-claude-code"
+  runtime = "claude_code"
+  team_id = controlplane_team.list.id
+}
+
+# Filter by team and name pattern
+data "controlplane_agents" "by_team" {
+  team_id    = controlplane_team.list.id
+  name_regex = "^test-agents-list-"
+
+  depends_on = [controlplane_agent.default_runtime, controlplane_agent.claude_code]
+}
+
+# Filter by team and runtime
+data "controlplane_agents" "claude_code" {
+  team_id = controlplane_team.list.id
+  runtime = "claude_code"
+
+  depends_on = [controlplane_agent.default_runtime, controlplane_agent.claude_code]
+}
+
+output "by_team_count" {
+  value = length(data.controlplane_agents.by_team.agents)
+}
+
+output "claude_code_names" {
+  value = [for a in data.controlplane_agents.claude_code.agents : a.name]
+}
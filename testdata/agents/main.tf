@@ -51,6 +51,11 @@ data "controlplane_agent" "claude_code_lookup" {
   id = controlplane_agent.claude_code.id
 }
 
+# Lookup by name instead of ID
+data "controlplane_agent" "full_by_name" {
+  name = controlplane_agent.full.name
+}
+
 # Outputs for test validation
 output "full_agent_name" {
   value = controlplane_agent.full.name
@@ -67,3 +72,11 @@ output "data_full_capabilities" {
 output "data_claude_code_runtime" {
   value = data.controlplane_agent.claude_code_lookup.runtime
 }
+
+output "full_agent_id" {
+  value = controlplane_agent.full.id
+}
+
+output "data_by_name_id" {
+  value = data.controlplane_agent.full_by_name.id
+}
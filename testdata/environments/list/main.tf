@@ -0,0 +1,49 @@
+provider "controlplane" {}
+
+# The environments are created by the test: alpha (ready, tags prod and eu), beta (inactive, tag prod)
+# and gamma (ready, tag dev), next to an environment the name pattern leaves out
+
+data "controlplane_environments" "by_name" {
+  name_regex = "^test-environments-list-"
+}
+
+data "controlplane_environments" "inactive" {
+  name_regex = "^test-environments-list-"
+  status     = "inactive"
+}
+
+data "controlplane_environments" "prod" {
+  name_regex = "^test-environments-list-"
+  tags       = ["prod"]
+}
+
+data "controlplane_environments" "prod_eu" {
+  name_regex = "^test-environments-list-"
+  tags       = ["prod", "eu"]
+}
+
+data "controlplane_environments" "none" {
+  name_regex = "^test-environments-list-"
+  tags       = ["dev", "eu"]
+}
+
+# Outputs
+output "by_name_names" {
+  value = [for environment in data.controlplane_environments.by_name.environments : environment.name]
+}
+
+output "inactive_names" {
+  value = [for environment in data.controlplane_environments.inactive.environments : environment.name]
+}
+
+output "prod_names" {
+  value = [for environment in data.controlplane_environments.prod.environments : environment.name]
+}
+
+output "prod_eu_names" {
+  value = [for environment in data.controlplane_environments.prod_eu.environments : environment.name]
+}
+
+output "none_count" {
+  value = length(data.controlplane_environments.none.environments)
+}
@@ -0,0 +1,58 @@
+
+provider "controlplane" {}
+
+resource "controlplane_policy" "guardrail" {
+  name           = "test-policy-attachment"
+  enabled        = true
+  policy_content = <<-EOT
+    package kubiya.guardrail
+
+    default allow := false
+  EOT
+}
+
+resource "controlplane_team" "platform" {
+  name    = "test-policy-attachment-team"
+  runtime = "default"
+}
+
+resource "controlplane_environment" "production" {
+  name = "test-policy-attachment-environment"
+}
+
+resource "controlplane_project" "shared" {
+  name = "test-policy-attachment-project"
+  key  = "TPAT"
+}
+
+# Attachments owned by this configuration, independent of the project's policy_ids
+resource "controlplane_policy_attachment" "team" {
+  policy_id   = controlplane_policy.guardrail.id
+  target_type = "team"
+  target_id   = controlplane_team.platform.id
+}
+
+resource "controlplane_policy_attachment" "environment" {
+  policy_id   = controlplane_policy.guardrail.id
+  target_type = "environment"
+  target_id   = controlplane_environment.production.id
+}
+
+resource "controlplane_policy_attachment" "project" {
+  policy_id   = controlplane_policy.guardrail.id
+  target_type = "project"
+  target_id   = controlplane_project.shared.id
+}
+
+# Outputs
+output "policy_id" {
+  value = controlplane_policy.guardrail.id
+}
+
+output "team_id" {
+  value = controlplane_team.platform.id
+}
+
+output "project_id" {
+  value = controlplane_project.shared.id
+}
@@ -0,0 +1,18 @@
+
+provider "controlplane" {}
+
+variable "policy_dir" {
+  type        = string
+  description = "Directory holding the .rego files of the bundle"
+}
+
+# One policy per .rego file in policy_dir; _test.rego files are skipped
+resource "controlplane_policy_bundle" "guardrails" {
+  name      = "test-bundle-guardrails"
+  path_glob = "${var.policy_dir}/*.rego"
+}
+
+# Outputs
+output "policy_ids" {
+  value = controlplane_policy_bundle.guardrails.policy_ids
+}
@@ -0,0 +1,29 @@
+
+variable "skip_conflict_check" {
+  type    = bool
+  default = false
+}
+
+variable "description" {
+  type    = string
+  default = "Managed by Terraform"
+}
+
+provider "controlplane" {
+  skip_conflict_check = var.skip_conflict_check
+}
+
+resource "controlplane_policy" "test" {
+  name        = "test-policy-conflict"
+  description = var.description
+  policy_type = "rego"
+  enabled     = true
+  policy_content = <<-EOT
+    package test_conflict
+    default allow := true
+  EOT
+}
+
+output "policy_id" {
+  value = controlplane_policy.test.id
+}
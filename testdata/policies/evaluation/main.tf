@@ -0,0 +1,63 @@
+
+provider "controlplane" {}
+
+locals {
+  tool_access = <<-EOT
+    package kubiya.tools
+
+    default allow := false
+
+    allow if {
+      input.tool == "kubectl"
+      input.user.groups[_] == "sre"
+    }
+
+    deny contains msg if {
+      not allow
+      msg := sprintf("%s may not use %s", [input.user.name, input.tool])
+    }
+  EOT
+}
+
+# Policy stored in the control plane, evaluated by ID below
+resource "controlplane_policy" "tool_access" {
+  name           = "test-policy-evaluation"
+  enabled        = true
+  policy_content = local.tool_access
+}
+
+data "controlplane_policy_evaluation" "allowed" {
+  policy_content = local.tool_access
+  query          = "data.kubiya.tools.allow"
+  input = jsonencode({
+    tool = "kubectl"
+    user = { name = "alice", groups = ["sre"] }
+  })
+}
+
+data "controlplane_policy_evaluation" "denied" {
+  policy_id = controlplane_policy.tool_access.id
+  query     = "data.kubiya.tools.deny"
+  input = jsonencode({
+    tool = "kubectl"
+    user = { name = "bob", groups = ["marketing"] }
+  })
+}
+
+data "controlplane_policy_evaluation" "undefined" {
+  policy_content = local.tool_access
+  query          = "data.kubiya.tools.missing"
+}
+
+# Outputs
+output "allowed_result" {
+  value = data.controlplane_policy_evaluation.allowed.result
+}
+
+output "denied_result" {
+  value = data.controlplane_policy_evaluation.denied.result
+}
+
+output "undefined_defined" {
+  value = data.controlplane_policy_evaluation.undefined.defined
+}
@@ -0,0 +1,33 @@
+
+provider "controlplane" {}
+
+# The second test expects marketing to be allowed, which the policy denies, so the plan must fail
+resource "controlplane_policy" "failing_tests" {
+  name = "test-policy-failing-tests"
+  policy_content = <<-EOT
+    package kubiya.tools
+
+    default allow := false
+
+    allow if {
+      input.tool == "kubectl"
+      input.user.groups[_] == "sre"
+    }
+  EOT
+
+  tests = [
+    <<-EOT
+      package kubiya.tools_test
+
+      import data.kubiya.tools
+
+      test_allows_sre if {
+        tools.allow with input as {"tool": "kubectl", "user": {"groups": ["sre"]}}
+      }
+
+      test_allows_marketing if {
+        tools.allow with input as {"tool": "kubectl", "user": {"groups": ["marketing"]}}
+      }
+    EOT
+  ]
+}
@@ -0,0 +1,9 @@
+
+provider "controlplane" {}
+
+# target_type is not a kind of object policies can be attached to
+resource "controlplane_policy_attachment" "invalid" {
+  policy_id   = "policy-id"
+  target_type = "workflow"
+  target_id   = "workflow-id"
+}
@@ -0,0 +1,13 @@
+
+provider "controlplane" {}
+
+# The document has a trailing comma, so the plan must fail before anything is created
+resource "controlplane_policy" "invalid_json" {
+  name        = "test-policy-invalid-json"
+  policy_type = "json"
+  policy_content = <<-EOT
+    {
+      "allow": true,
+    }
+  EOT
+}
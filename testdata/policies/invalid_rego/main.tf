@@ -0,0 +1,14 @@
+
+provider "controlplane" {}
+
+# The rule body is never closed, so the plan must fail before anything is created
+resource "controlplane_policy" "invalid_rego" {
+  name        = "test-policy-invalid-rego"
+  policy_type = "rego"
+  policy_content = <<-EOT
+    package test_invalid
+
+    allow if {
+      input.user == "admin"
+  EOT
+}
@@ -0,0 +1,51 @@
+provider "controlplane" {}
+
+# The policies are created by the test: alpha (rego, enabled, tag security), beta (json, disabled,
+# tags security and cost) and gamma (rego, disabled, tag cost), next to a policy the name pattern
+# leaves out
+
+data "controlplane_policies" "by_name" {
+  name_regex = "^test-policies-list-"
+}
+
+data "controlplane_policies" "json" {
+  name_regex  = "^test-policies-list-"
+  policy_type = "json"
+}
+
+data "controlplane_policies" "disabled" {
+  name_regex = "^test-policies-list-"
+  enabled    = false
+}
+
+data "controlplane_policies" "security" {
+  name_regex = "^test-policies-list-"
+  tags       = ["security"]
+}
+
+data "controlplane_policies" "none" {
+  name_regex  = "^test-policies-list-"
+  policy_type = "rego"
+  tags        = ["security", "cost"]
+}
+
+# Outputs
+output "by_name_names" {
+  value = [for policy in data.controlplane_policies.by_name.policies : policy.name]
+}
+
+output "json_names" {
+  value = [for policy in data.controlplane_policies.json.policies : policy.name]
+}
+
+output "disabled_names" {
+  value = [for policy in data.controlplane_policies.disabled.policies : policy.name]
+}
+
+output "security_names" {
+  value = [for policy in data.controlplane_policies.security.policies : policy.name]
+}
+
+output "none_count" {
+  value = length(data.controlplane_policies.none.policies)
+}
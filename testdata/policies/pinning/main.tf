@@ -0,0 +1,37 @@
+
+provider "controlplane" {}
+
+variable "policy_content" {
+  type        = string
+  description = "Rego content of the policy"
+}
+
+variable "pinned_version" {
+  type        = number
+  description = "Version of the policy to roll back to"
+  default     = null
+}
+
+resource "controlplane_policy" "pinned" {
+  name           = "test-policy-pinning"
+  enabled        = true
+  policy_content = var.policy_content
+  pinned_version = var.pinned_version
+}
+
+data "controlplane_policy_versions" "pinned" {
+  policy_id = controlplane_policy.pinned.id
+}
+
+# Outputs
+output "policy_id" {
+  value = controlplane_policy.pinned.id
+}
+
+output "version" {
+  value = controlplane_policy.pinned.version
+}
+
+output "versions" {
+  value = [for v in data.controlplane_policy_versions.pinned.versions : v.version]
+}
@@ -0,0 +1,40 @@
+provider "controlplane" {}
+
+# The projects are created by the test: alpha (active, private), beta (paused, org) and gamma
+# (active, org), next to a project the name pattern leaves out
+
+data "controlplane_projects" "by_name" {
+  name_regex = "^test-projects-list-"
+}
+
+data "controlplane_projects" "paused" {
+  name_regex = "^test-projects-list-"
+  status     = "paused"
+}
+
+data "controlplane_projects" "org" {
+  name_regex = "^test-projects-list-"
+  visibility = "org"
+}
+
+data "controlplane_projects" "none" {
+  name_regex = "^test-projects-list-"
+  status     = "archived"
+}
+
+# Outputs
+output "by_name_names" {
+  value = [for project in data.controlplane_projects.by_name.projects : project.name]
+}
+
+output "paused_names" {
+  value = [for project in data.controlplane_projects.paused.projects : project.name]
+}
+
+output "org_names" {
+  value = [for project in data.controlplane_projects.org.projects : project.name]
+}
+
+output "none_count" {
+  value = length(data.controlplane_projects.none.projects)
+}
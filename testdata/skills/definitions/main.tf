@@ -0,0 +1,32 @@
+
+provider "controlplane" {}
+
+data "controlplane_skill_definitions" "all" {}
+
+locals {
+  definitions = { for d in data.controlplane_skill_definitions.all.definitions : d.type => d }
+}
+
+# Skill of a custom type registered by the organization
+resource "controlplane_skill" "custom" {
+  name    = "test-skill-definitions-custom"
+  type    = "jira"
+  enabled = true
+
+  configuration = jsonencode({
+    project = "OPS"
+  })
+}
+
+# Outputs
+output "types" {
+  value = keys(local.definitions)
+}
+
+output "custom_types" {
+  value = [for d in data.controlplane_skill_definitions.all.definitions : d.type if d.custom]
+}
+
+output "shell_schema" {
+  value = local.definitions["shell"].configuration_schema
+}
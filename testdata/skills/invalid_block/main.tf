@@ -0,0 +1,12 @@
+
+provider "controlplane" {}
+
+# The docker block does not belong to a shell skill
+resource "controlplane_skill" "invalid" {
+  name = "test-skill-invalid-block"
+  type = "shell"
+
+  docker {
+    allowed_images = ["alpine"]
+  }
+}
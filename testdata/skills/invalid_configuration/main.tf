@@ -0,0 +1,16 @@
+
+provider "controlplane" {}
+
+# timeout must be positive and the shell schema has no bogus key
+resource "controlplane_skill" "invalid" {
+  name = "test-skill-invalid-configuration"
+  type = "shell"
+
+  configuration = jsonencode({
+    bogus = true
+  })
+
+  shell {
+    timeout = 0
+  }
+}
@@ -0,0 +1,42 @@
+provider "controlplane" {}
+
+# The skills are created by the test: alpha (shell, enabled), beta (file_system, disabled) and gamma
+# (shell, disabled), next to a skill the name pattern leaves out
+
+data "controlplane_skills" "by_name" {
+  name_regex = "^test-skills-list-"
+}
+
+data "controlplane_skills" "shell" {
+  name_regex = "^test-skills-list-"
+  type       = "shell"
+}
+
+data "controlplane_skills" "disabled_shell" {
+  name_regex = "^test-skills-list-"
+  type       = "shell"
+  enabled    = false
+}
+
+data "controlplane_skills" "none" {
+  name_regex = "^test-skills-list-"
+  type       = "file_system"
+  enabled    = true
+}
+
+# Outputs
+output "by_name_names" {
+  value = [for skill in data.controlplane_skills.by_name.skills : skill.name]
+}
+
+output "shell_names" {
+  value = [for skill in data.controlplane_skills.shell.skills : skill.name]
+}
+
+output "disabled_shell_names" {
+  value = [for skill in data.controlplane_skills.disabled_shell.skills : skill.name]
+}
+
+output "none_count" {
+  value = length(data.controlplane_skills.none.skills)
+}
@@ -0,0 +1,26 @@
+
+provider "controlplane" {}
+
+variable "skill_type" {
+  type    = string
+  default = "shell"
+}
+
+variable "enabled" {
+  type    = bool
+  default = null
+}
+
+resource "controlplane_skill" "toggle" {
+  name    = "test-skill-toggle"
+  type    = var.skill_type
+  enabled = var.enabled
+}
+
+output "skill_id" {
+  value = controlplane_skill.toggle.id
+}
+
+output "enabled" {
+  value = controlplane_skill.toggle.enabled
+}
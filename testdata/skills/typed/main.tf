@@ -0,0 +1,41 @@
+
+provider "controlplane" {}
+
+# Shell skill configured through its typed block
+resource "controlplane_skill" "shell" {
+  name    = "test-skill-typed-shell"
+  type    = "shell"
+  enabled = true
+
+  shell {
+    allowed_commands = ["kubectl", "helm"]
+    blocked_commands = ["rm"]
+    timeout          = 300
+  }
+}
+
+# Typed block merged with keys that have no typed attribute
+resource "controlplane_skill" "file_system" {
+  name    = "test-skill-typed-file-system"
+  type    = "file_system"
+  enabled = true
+
+  configuration = jsonencode({
+    max_file_size = 10485760
+  })
+
+  file_system {
+    base_dir      = "/app"
+    read_only     = true
+    allowed_paths = ["/app", "/tmp"]
+  }
+}
+
+# Outputs
+output "shell_skill_id" {
+  value = controlplane_skill.shell.id
+}
+
+output "file_system_skill_id" {
+  value = controlplane_skill.file_system.id
+}
@@ -0,0 +1,8 @@
+
+provider "controlplane" {}
+
+# jira is not registered in the organization
+resource "controlplane_skill" "unsupported" {
+  name = "test-skill-unsupported-type"
+  type = "jira"
+}
@@ -0,0 +1,18 @@
+
+provider "controlplane" {}
+
+# A team has at most one leader
+resource "controlplane_team_members" "two_leaders" {
+  team_id = "00000000-0000-0000-0000-000000000001"
+
+  members = [
+    { agent_id = "00000000-0000-0000-0000-000000000002", role = "leader" },
+    { agent_id = "00000000-0000-0000-0000-000000000003", role = "leader" },
+  ]
+}
+
+resource "controlplane_team_member" "owner" {
+  team_id  = "00000000-0000-0000-0000-000000000001"
+  agent_id = "00000000-0000-0000-0000-000000000004"
+  role     = "owner"
+}
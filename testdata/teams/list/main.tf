@@ -0,0 +1,41 @@
+provider "controlplane" {}
+
+# The teams are created by the test: alpha (active, default runtime), beta (inactive, claude_code)
+# and gamma (archived, default runtime), next to a team the name pattern leaves out
+
+data "controlplane_teams" "by_name" {
+  name_regex = "^test-teams-list-"
+}
+
+data "controlplane_teams" "inactive" {
+  name_regex = "^test-teams-list-"
+  status     = "inactive"
+}
+
+data "controlplane_teams" "claude_code" {
+  name_regex = "^test-teams-list-"
+  runtime    = "claude_code"
+}
+
+data "controlplane_teams" "none" {
+  name_regex = "^test-teams-list-"
+  status     = "inactive"
+  runtime    = "default"
+}
+
+# Outputs
+output "by_name_names" {
+  value = [for team in data.controlplane_teams.by_name.teams : team.name]
+}
+
+output "inactive_names" {
+  value = [for team in data.controlplane_teams.inactive.teams : team.name]
+}
+
+output "claude_code_names" {
+  value = [for team in data.controlplane_teams.claude_code.teams : team.name]
+}
+
+output "none_count" {
+  value = length(data.controlplane_teams.none.teams)
+}
@@ -0,0 +1,74 @@
+
+provider "controlplane" {}
+
+variable "platform_members" {
+  type    = list(string)
+  default = ["alpha", "beta"]
+}
+
+variable "platform_leader" {
+  type    = string
+  default = "alpha"
+}
+
+resource "controlplane_team" "platform" {
+  name    = "test-team-members-platform"
+  runtime = "default"
+}
+
+resource "controlplane_team" "security" {
+  name    = "test-team-members-security"
+  runtime = "default"
+}
+
+resource "controlplane_agent" "this" {
+  for_each = toset(["alpha", "beta", "gamma"])
+
+  name    = "test-team-members-${each.key}"
+  runtime = "default"
+}
+
+# Every agent of the platform team
+resource "controlplane_team_members" "platform" {
+  team_id = controlplane_team.platform.id
+
+  members = [
+    for key in var.platform_members : {
+      agent_id = controlplane_agent.this[key].id
+      role     = key == var.platform_leader ? "leader" : null
+    }
+  ]
+}
+
+# A single agent of the security team
+resource "controlplane_team_member" "gamma" {
+  team_id  = controlplane_team.security.id
+  agent_id = controlplane_agent.this["gamma"].id
+}
+
+data "controlplane_team" "platform" {
+  id = controlplane_team.platform.id
+
+  depends_on = [controlplane_team_members.platform]
+}
+
+# Outputs
+output "platform_agents" {
+  value = { for agent in data.controlplane_team.platform.agents : agent.name => agent.role }
+}
+
+output "gamma_role" {
+  value = controlplane_team_member.gamma.role
+}
+
+output "agent_ids" {
+  value = { for key, agent in controlplane_agent.this : key => agent.id }
+}
+
+output "platform_team_id" {
+  value = controlplane_team.platform.id
+}
+
+output "security_team_id" {
+  value = controlplane_team.security.id
+}
@@ -0,0 +1,47 @@
+
+provider "controlplane" {}
+
+# Set once the agent exists, so that the membership does not reference it and can be written first
+variable "agent_id" {
+  type    = string
+  default = ""
+}
+
+variable "description" {
+  type    = string
+  default = "Before the team change"
+}
+
+resource "controlplane_team" "platform" {
+  name    = "test-team-members-update-platform"
+  runtime = "default"
+}
+
+# Updated in the same apply that adds it to the team
+resource "controlplane_agent" "alpha" {
+  name        = "test-team-members-update-alpha"
+  description = var.description
+  runtime     = "default"
+  status      = "idle"
+
+  depends_on = [controlplane_team_members.platform]
+}
+
+resource "controlplane_team_members" "platform" {
+  count = var.agent_id == "" ? 0 : 1
+
+  team_id = controlplane_team.platform.id
+
+  members = [{
+    agent_id = var.agent_id
+  }]
+}
+
+# Outputs
+output "agent_id" {
+  value = controlplane_agent.alpha.id
+}
+
+output "platform_team_id" {
+  value = controlplane_team.platform.id
+}
@@ -0,0 +1,29 @@
+
+provider "controlplane" {}
+
+variable "force_destroy" {
+  type    = bool
+  default = false
+}
+
+variable "drain_timeout" {
+  type    = number
+  default = 60
+}
+
+resource "controlplane_environment" "test" {
+  name = "test-environment-worker-queue-drain"
+}
+
+resource "controlplane_worker_queue" "test" {
+  name           = "test-worker-queue-drain"
+  display_name   = "Worker Queue Drain"
+  description    = "Worker queue drained on destroy"
+  environment_id = controlplane_environment.test.id
+  force_destroy  = var.force_destroy
+  drain_timeout  = var.drain_timeout
+}
+
+output "worker_queue_id" {
+  value = controlplane_worker_queue.test.id
+}
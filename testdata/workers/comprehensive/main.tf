@@ -29,13 +29,15 @@ resource "controlplane_worker_queue" "full" {
 
   tags = ["production", "critical", "monitored"]
 
-  settings = {
-    priority          = "high"
-    auto_scale        = "true"
-    min_workers       = "2"
-    scale_up_threshold = "80"
-    scale_down_threshold = "20"
-  }
+  settings = jsonencode({
+    priority = "high"
+    autoscaling = {
+      enabled              = true
+      min_workers          = 2
+      scale_up_threshold   = 80
+      scale_down_threshold = 20
+    }
+  })
 }
 
 # Test 3: Worker queue with high worker limit
@@ -102,12 +104,12 @@ resource "controlplane_worker_queue" "with_settings" {
   name           = "test-wq-with-settings"
   description    = "Worker queue with custom settings"
 
-  settings = {
+  settings = jsonencode({
     region            = "us-west-2"
     availability_zone = "us-west-2a"
     instance_type     = "t3.medium"
-    enable_spot       = "true"
-  }
+    enable_spot       = true
+  })
 }
 
 # Test 11: Worker queue with complex settings
@@ -116,15 +118,16 @@ resource "controlplane_worker_queue" "complex_settings" {
   name           = "test-wq-complex-settings"
   description    = "Worker queue with complex settings"
 
-  settings = {
+  settings = jsonencode({
     # Autoscaling configuration
-    auto_scale_enabled    = "true"
-    min_workers           = "1"
-    max_workers_limit     = "50"
-    scale_up_threshold    = "85"
-    scale_down_threshold  = "15"
-    scale_up_cooldown     = "300"
-    scale_down_cooldown   = "600"
+    autoscaling = {
+      enabled              = true
+      min_workers          = 1
+      max_workers          = 50
+      scale_up_threshold   = 85
+      scale_down_threshold = 15
+      cooldown_seconds     = 300
+    }
 
     # Resource configuration
     cpu_limit             = "2000m"
@@ -133,12 +136,12 @@ resource "controlplane_worker_queue" "complex_settings" {
 
     # Network configuration
     network_policy        = "default"
-    egress_enabled        = "true"
+    egress_enabled        = true
 
     # Monitoring
-    metrics_enabled       = "true"
+    metrics_enabled       = true
     log_level             = "info"
-  }
+  })
 }
 
 # Test 12: Worker queue with empty optional fields
@@ -148,7 +151,7 @@ resource "controlplane_worker_queue" "empty_optionals" {
   display_name   = ""
   description    = ""
   tags           = []
-  settings       = {}
+  settings       = jsonencode({})
 }
 
 # Test 13: Worker queue for update testing
@@ -159,9 +162,9 @@ resource "controlplane_worker_queue" "for_update" {
   status         = "active"
   max_workers    = 5
 
-  settings = {
-    version = "1"
-  }
+  settings = jsonencode({
+    version = 1
+  })
 }
 
 # Create second environment for testing multiple environments